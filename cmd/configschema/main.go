@@ -0,0 +1,65 @@
+// Command configschema regenerates the configSchema of plugin.yaml from the plugin config structs (pkg/pluginconfig).
+// configSchema must be the last top-level key of the manifest, everything above it is kept as is.
+//
+// Usage: go run ./cmd/configschema [plugin.yaml]
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/pluginconfig"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+// schemaHeader is written above the generated configSchema.
+const schemaHeader = "# Generated from pkg/pluginconfig by `go generate`, DO NOT EDIT.\n"
+
+var topLevelKeyPattern = regexp.MustCompile(`(?m)^[A-Za-z_]\w*:`)
+
+func main() {
+	manifestPath := "plugin.yaml"
+	if len(os.Args) > 1 {
+		manifestPath = os.Args[1]
+	}
+	if generateErr := generateConfigSchema(manifestPath); generateErr != nil {
+		fmt.Fprintln(os.Stderr, "Error:", generateErr)
+		os.Exit(1)
+	}
+}
+
+func generateConfigSchema(manifestPath string) error {
+	manifest, readErr := os.ReadFile(manifestPath)
+	if readErr != nil {
+		return readErr
+	}
+
+	schemaStart := bytes.Index(manifest, []byte("\nconfigSchema:"))
+	if schemaStart < 0 {
+		return fmt.Errorf("%s: missing top-level configSchema key", manifestPath)
+	}
+	schemaStart++
+	if topLevelKeyPattern.Match(manifest[schemaStart+len("configSchema:"):]) {
+		return fmt.Errorf("%s: configSchema must be the last top-level key", manifestPath)
+	}
+	manifestHead := bytes.TrimSuffix(manifest[:schemaStart], []byte(schemaHeader))
+
+	var schemaYAML bytes.Buffer
+	encoder := yaml3.NewEncoder(&schemaYAML)
+	encoder.SetIndent(2)
+	encodeErr := encoder.Encode(map[string]any{
+		"configSchema": pluginconfig.GetConfigSchema(),
+	})
+	if encodeErr != nil {
+		return fmt.Errorf("error encoding configSchema: %w", encodeErr)
+	}
+	if closeErr := encoder.Close(); closeErr != nil {
+		return fmt.Errorf("error encoding configSchema: %w", closeErr)
+	}
+
+	generatedManifest := append(manifestHead, []byte(schemaHeader)...)
+	generatedManifest = append(generatedManifest, schemaYAML.Bytes()...)
+	return os.WriteFile(manifestPath, generatedManifest, 0644)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	plugin "github.com/kalo-build/plugin-morphe-go-struct"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/pluginconfig"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+type pluginManifest struct {
+	ConfigSchema map[string]pluginconfig.SchemaProperty `yaml:"configSchema"`
+}
+
+// configIssue is a value of the raw config that does not match the configSchema, ie. an unknown key.
+type configIssue struct {
+	// Path is the dotted key path, ie. "config.models.packagePath"
+	Path    string
+	Message string
+}
+
+func (i configIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+func loadConfigSchema() (map[string]pluginconfig.SchemaProperty, error) {
+	var manifest pluginManifest
+	if unmarshalErr := yaml3.Unmarshal(plugin.Manifest, &manifest); unmarshalErr != nil {
+		return nil, fmt.Errorf("error parsing plugin.yaml: %w", unmarshalErr)
+	}
+	return manifest.ConfigSchema, nil
+}
+
+// validateRawConfig reports unknown keys of the raw config, and values under "config" that do not match the type or enum
+// of their configSchema property. Missing required values are reported by the dedicated checks of main.
+func validateRawConfig(rawConfig map[string]any, configSchema map[string]pluginconfig.SchemaProperty) []configIssue {
+	allIssues := []configIssue{}
+	knownKeys := pluginconfig.GetConfigKeys()
+	for _, key := range core.MapKeysSorted(rawConfig) {
+		if !slices.Contains(knownKeys, key) {
+			allIssues = append(allIssues, configIssue{
+				Path:    key,
+				Message: getUnknownKeyMessage(key, knownKeys),
+			})
+		}
+	}
+
+	configValue, hasConfig := rawConfig["config"]
+	if !hasConfig || configValue == nil {
+		return allIssues
+	}
+	return append(allIssues, validateConfigValue("config", configValue, pluginconfig.SchemaProperty{
+		Type:       "object",
+		Properties: configSchema,
+	})...)
+}
+
+func validateConfigValue(path string, value any, property pluginconfig.SchemaProperty) []configIssue {
+	if value == nil {
+		return nil
+	}
+	if !isConfigSchemaType(value, property.Type) {
+		return []configIssue{
+			{
+				Path:    path,
+				Message: fmt.Sprintf("must be of type %s", property.Type),
+			},
+		}
+	}
+	if len(property.Enum) > 0 && !isConfigSchemaEnumValue(value, property.Enum) {
+		return []configIssue{
+			{
+				Path:    path,
+				Message: fmt.Sprintf("invalid value %q, must be one of: %s", fmt.Sprint(value), getEnumValuesString(property.Enum)),
+			},
+		}
+	}
+
+	objectValue, isObject := value.(map[string]any)
+	if !isObject {
+		return nil
+	}
+	allIssues := []configIssue{}
+	for _, key := range core.MapKeysSorted(objectValue) {
+		keyPath := path + "." + key
+		// Maps hold arbitrary keys, ie. the type overrides by field type, whose values share one property
+		if property.AdditionalProperties != nil {
+			allIssues = append(allIssues, validateConfigValue(keyPath, objectValue[key], *property.AdditionalProperties)...)
+			continue
+		}
+		keyProperty, isKnown := property.Properties[key]
+		if !isKnown {
+			allIssues = append(allIssues, configIssue{
+				Path:    keyPath,
+				Message: getUnknownKeyMessage(key, core.MapKeysSorted(property.Properties)),
+			})
+			continue
+		}
+		allIssues = append(allIssues, validateConfigValue(keyPath, objectValue[key], keyProperty)...)
+	}
+	return allIssues
+}
+
+// getUnknownKeyMessage suggests the known key that only differs in casing, ie. "packagePath" for "PackagePath".
+func getUnknownKeyMessage(key string, allKnownKeys []string) string {
+	for _, knownKey := range allKnownKeys {
+		if strings.EqualFold(key, knownKey) {
+			return fmt.Sprintf("unknown key, did you mean %q?", knownKey)
+		}
+	}
+	return "unknown key"
+}
+
+func isConfigSchemaType(value any, schemaType string) bool {
+	switch schemaType {
+	case "string":
+		_, isString := value.(string)
+		return isString
+	case "boolean":
+		_, isBool := value.(bool)
+		return isBool
+	case "integer", "number":
+		switch value.(type) {
+		case int, int64, float64:
+			return true
+		}
+		return false
+	case "array":
+		_, isArray := value.([]any)
+		return isArray
+	case "object":
+		_, isObject := value.(map[string]any)
+		return isObject
+	}
+	return true
+}
+
+func isConfigSchemaEnumValue(value any, allEnumValues []any) bool {
+	for _, enumValue := range allEnumValues {
+		if value == enumValue {
+			return true
+		}
+	}
+	return false
+}
+
+func getEnumValuesString(allEnumValues []any) string {
+	allValueStrings := []string{}
+	for _, enumValue := range allEnumValues {
+		allValueStrings = append(allValueStrings, fmt.Sprintf("%q", fmt.Sprint(enumValue)))
+	}
+	return strings.Join(allValueStrings, ", ")
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/pluginconfig"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigSchemaTestSuite struct {
+	suite.Suite
+
+	configSchema map[string]pluginconfig.SchemaProperty
+}
+
+func TestConfigSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigSchemaTestSuite))
+}
+
+func (suite *ConfigSchemaTestSuite) SetupTest() {
+	configSchema, loadErr := loadConfigSchema()
+	suite.Require().NoError(loadErr)
+	suite.configSchema = configSchema
+}
+
+func (suite *ConfigSchemaTestSuite) TestValidateRawConfig_Valid() {
+	rawConfig := map[string]any{
+		"inputPath":  "./registry",
+		"outputPath": "./generated",
+		"verbose":    true,
+		"config": map[string]any{
+			"fieldCasing": "camel",
+			"models": map[string]any{
+				"packagePath": "github.com/example/models",
+				"jsonTags": map[string]any{
+					"fields": map[string]any{
+						"Person.ID": map[string]any{
+							"name":   "id",
+							"string": true,
+						},
+					},
+				},
+			},
+			"typeOverrides": map[string]any{
+				"fieldTypes": map[string]any{
+					"UUID": map[string]any{
+						"packagePath": "github.com/google/uuid",
+						"name":        "UUID",
+					},
+				},
+			},
+		},
+	}
+
+	allIssues := validateRawConfig(rawConfig, suite.configSchema)
+
+	suite.Empty(allIssues)
+}
+
+func (suite *ConfigSchemaTestSuite) TestValidateRawConfig_NullConfig() {
+	allIssues := validateRawConfig(map[string]any{"config": nil}, suite.configSchema)
+
+	suite.Empty(allIssues)
+}
+
+func (suite *ConfigSchemaTestSuite) TestValidateRawConfig_Issues() {
+	allCases := []struct {
+		name           string
+		rawConfig      map[string]any
+		expectedIssues []configIssue
+	}{
+		{
+			name: "unknown top-level key",
+			rawConfig: map[string]any{
+				"inputpath": "./registry",
+			},
+			expectedIssues: []configIssue{
+				{Path: "inputpath", Message: `unknown key, did you mean "inputPath"?`},
+			},
+		},
+		{
+			name: "unknown section key",
+			rawConfig: map[string]any{
+				"config": map[string]any{
+					"models": map[string]any{
+						"packagePath": "github.com/example/models",
+						"pakageName":  "models",
+					},
+				},
+			},
+			expectedIssues: []configIssue{
+				{Path: "config.models.pakageName", Message: "unknown key"},
+			},
+		},
+		{
+			name: "section key of another casing",
+			rawConfig: map[string]any{
+				"config": map[string]any{
+					"entities": map[string]any{
+						"PackagePath": "github.com/example/entities",
+						"ormTags": map[string]any{
+							"Gorm": true,
+						},
+					},
+				},
+			},
+			expectedIssues: []configIssue{
+				{Path: "config.entities.PackagePath", Message: `unknown key, did you mean "packagePath"?`},
+				{Path: "config.entities.ormTags.Gorm", Message: `unknown key, did you mean "gorm"?`},
+			},
+		},
+		{
+			name: "mistyped value",
+			rawConfig: map[string]any{
+				"config": map[string]any{
+					"enums": map[string]any{
+						"textMarshalling": "yes",
+					},
+				},
+			},
+			expectedIssues: []configIssue{
+				{Path: "config.enums.textMarshalling", Message: "must be of type boolean"},
+			},
+		},
+		{
+			name: "invalid enum value",
+			rawConfig: map[string]any{
+				"config": map[string]any{
+					"fieldCasing": "kebab",
+				},
+			},
+			expectedIssues: []configIssue{
+				{Path: "config.fieldCasing", Message: `invalid value "kebab", must be one of: "camel", "snake", "pascal", ""`},
+			},
+		},
+		{
+			name: "unknown key of a map entry",
+			rawConfig: map[string]any{
+				"config": map[string]any{
+					"typeOverrides": map[string]any{
+						"fieldTypes": map[string]any{
+							"Date": map[string]any{
+								"pakagePath": "cloud.google.com/go/civil",
+								"name":       "Date",
+							},
+						},
+					},
+				},
+			},
+			expectedIssues: []configIssue{
+				{Path: "config.typeOverrides.fieldTypes.Date.pakagePath", Message: "unknown key"},
+			},
+		},
+		{
+			name: "mistyped map entry",
+			rawConfig: map[string]any{
+				"config": map[string]any{
+					"typeOverrides": map[string]any{
+						"modelFields": map[string]any{
+							"Invoice.Amount": "github.com/shopspring/decimal.Decimal",
+						},
+					},
+					"entities": map[string]any{
+						"jsonTags": map[string]any{
+							"fields": map[string]any{
+								"Person.ID": map[string]any{
+									"string": "true",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedIssues: []configIssue{
+				{Path: "config.entities.jsonTags.fields.Person.ID.string", Message: "must be of type boolean"},
+				{Path: "config.typeOverrides.modelFields.Invoice.Amount", Message: "must be of type object"},
+			},
+		},
+	}
+
+	for _, testCase := range allCases {
+		suite.Run(testCase.name, func() {
+			allIssues := validateRawConfig(testCase.rawConfig, suite.configSchema)
+
+			suite.Equal(testCase.expectedIssues, allIssues)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/pluginconfig"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+// ConfigStdinArg reads the config from stdin instead of a JSON string or file.
+const ConfigStdinArg = "-"
+
+const (
+	// EnvInputPath overrides the inputPath of the config.
+	EnvInputPath = "MORPHE_GO_STRUCT_INPUT_PATH"
+	// EnvOutputPath overrides the outputPath of the config.
+	EnvOutputPath = "MORPHE_GO_STRUCT_OUTPUT_PATH"
+)
+
+// readRawConfig reads the config argument, which is either an inline JSON object, ConfigStdinArg for YAML or JSON on
+// stdin, or the path to a YAML (.yaml, .yml) or JSON config file.
+func readRawConfig(configArg string, stdin io.Reader) (map[string]any, error) {
+	if strings.HasPrefix(strings.TrimSpace(configArg), "{") {
+		return unmarshalRawConfig([]byte(configArg), true)
+	}
+
+	if configArg == ConfigStdinArg {
+		configContents, readErr := io.ReadAll(stdin)
+		if readErr != nil {
+			return nil, fmt.Errorf("error reading config from stdin: %w", readErr)
+		}
+		return unmarshalRawConfig(configContents, false)
+	}
+
+	configContents, readErr := os.ReadFile(configArg)
+	if readErr != nil {
+		return nil, fmt.Errorf("error reading config file: %w", readErr)
+	}
+	rawConfig, unmarshalErr := unmarshalRawConfig(configContents, strings.ToLower(filepath.Ext(configArg)) == ".json")
+	if unmarshalErr != nil {
+		return nil, fmt.Errorf("%s: %w", configArg, unmarshalErr)
+	}
+	return rawConfig, nil
+}
+
+// unmarshalRawConfig decodes JSON, or YAML (which includes JSON) into maps of keys to values. An empty config (ie. "null")
+// is an empty object, so that the env overrides can still be applied.
+func unmarshalRawConfig(configContents []byte, isJSON bool) (map[string]any, error) {
+	var rawConfig map[string]any
+	if isJSON {
+		if unmarshalErr := json.Unmarshal(configContents, &rawConfig); unmarshalErr != nil {
+			return nil, fmt.Errorf("error parsing config JSON: %w", unmarshalErr)
+		}
+	} else if unmarshalErr := yaml3.Unmarshal(configContents, &rawConfig); unmarshalErr != nil {
+		return nil, fmt.Errorf("error parsing config YAML: %w", unmarshalErr)
+	}
+	if rawConfig == nil {
+		rawConfig = map[string]any{}
+	}
+	return rawConfig, nil
+}
+
+// decodeCompileConfig converts the validated raw config into the CompileConfig.
+func decodeCompileConfig(rawConfig map[string]any) (pluginconfig.CompileConfig, error) {
+	configJSON, marshalErr := json.Marshal(rawConfig)
+	if marshalErr != nil {
+		return pluginconfig.CompileConfig{}, fmt.Errorf("error encoding config: %w", marshalErr)
+	}
+	var compileConfig pluginconfig.CompileConfig
+	if unmarshalErr := json.Unmarshal(configJSON, &compileConfig); unmarshalErr != nil {
+		return pluginconfig.CompileConfig{}, fmt.Errorf("error parsing config: %w", unmarshalErr)
+	}
+	return compileConfig, nil
+}
+
+// applyEnvOverrides sets the input and output paths from the environment, ie. to reuse a config file across machines.
+func applyEnvOverrides(rawConfig map[string]any) {
+	if inputPath := os.Getenv(EnvInputPath); inputPath != "" {
+		rawConfig["inputPath"] = inputPath
+	}
+	if outputPath := os.Getenv(EnvOutputPath); outputPath != "" {
+		rawConfig["outputPath"] = outputPath
+	}
+}
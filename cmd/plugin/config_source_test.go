@@ -0,0 +1,153 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigSourceTestSuite struct {
+	suite.Suite
+
+	configDirPath string
+}
+
+func TestConfigSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigSourceTestSuite))
+}
+
+func (suite *ConfigSourceTestSuite) SetupTest() {
+	suite.configDirPath = suite.T().TempDir()
+}
+
+func (suite *ConfigSourceTestSuite) writeConfigFile(fileName string, configContents string) string {
+	configFilePath := filepath.Join(suite.configDirPath, fileName)
+	suite.Require().NoError(os.WriteFile(configFilePath, []byte(configContents), 0644))
+	return configFilePath
+}
+
+func (suite *ConfigSourceTestSuite) TestReadRawConfig() {
+	allCases := []struct {
+		name              string
+		configArg         string
+		stdin             string
+		expectedRawConfig map[string]any
+	}{
+		{
+			name:      "inline JSON",
+			configArg: `{"inputPath":"./registry","verbose":true}`,
+			expectedRawConfig: map[string]any{
+				"inputPath": "./registry",
+				"verbose":   true,
+			},
+		},
+		{
+			name:      "YAML file",
+			configArg: suite.writeConfigFile("config.yaml", "inputPath: ./registry\nverbose: true\n"),
+			expectedRawConfig: map[string]any{
+				"inputPath": "./registry",
+				"verbose":   true,
+			},
+		},
+		{
+			name:      "JSON file",
+			configArg: suite.writeConfigFile("config.json", `{"inputPath":"./registry"}`),
+			expectedRawConfig: map[string]any{
+				"inputPath": "./registry",
+			},
+		},
+		{
+			name:      "YAML stdin",
+			configArg: ConfigStdinArg,
+			stdin:     "inputPath: ./registry\n",
+			expectedRawConfig: map[string]any{
+				"inputPath": "./registry",
+			},
+		},
+		{
+			name:      "JSON stdin",
+			configArg: ConfigStdinArg,
+			stdin:     `{"inputPath":"./registry"}`,
+			expectedRawConfig: map[string]any{
+				"inputPath": "./registry",
+			},
+		},
+		{
+			name:              "null stdin",
+			configArg:         ConfigStdinArg,
+			stdin:             "null",
+			expectedRawConfig: map[string]any{},
+		},
+		{
+			name:              "empty stdin",
+			configArg:         ConfigStdinArg,
+			expectedRawConfig: map[string]any{},
+		},
+		{
+			name:              "null JSON file",
+			configArg:         suite.writeConfigFile("null.json", "null"),
+			expectedRawConfig: map[string]any{},
+		},
+	}
+
+	for _, testCase := range allCases {
+		suite.Run(testCase.name, func() {
+			rawConfig, readErr := readRawConfig(testCase.configArg, strings.NewReader(testCase.stdin))
+
+			suite.NoError(readErr)
+			suite.Equal(testCase.expectedRawConfig, rawConfig)
+		})
+	}
+}
+
+func (suite *ConfigSourceTestSuite) TestReadRawConfig_Errors() {
+	allCases := []struct {
+		name          string
+		configArg     string
+		stdin         string
+		expectedError string
+	}{
+		{
+			name:          "invalid inline JSON",
+			configArg:     `{"inputPath":`,
+			expectedError: "error parsing config JSON",
+		},
+		{
+			name:          "non-object YAML stdin",
+			configArg:     ConfigStdinArg,
+			stdin:         "- inputPath\n",
+			expectedError: "error parsing config YAML",
+		},
+		{
+			name:          "missing file",
+			configArg:     filepath.Join(suite.configDirPath, "missing.yaml"),
+			expectedError: "error reading config file",
+		},
+	}
+
+	for _, testCase := range allCases {
+		suite.Run(testCase.name, func() {
+			rawConfig, readErr := readRawConfig(testCase.configArg, strings.NewReader(testCase.stdin))
+
+			suite.ErrorContains(readErr, testCase.expectedError)
+			suite.Nil(rawConfig)
+		})
+	}
+}
+
+func (suite *ConfigSourceTestSuite) TestApplyEnvOverrides_NullConfig() {
+	suite.T().Setenv(EnvInputPath, "./registry")
+	suite.T().Setenv(EnvOutputPath, "./generated")
+	rawConfig, readErr := readRawConfig(ConfigStdinArg, strings.NewReader("null"))
+	suite.Require().NoError(readErr)
+
+	applyEnvOverrides(rawConfig)
+
+	suite.Equal(map[string]any{
+		"inputPath":  "./registry",
+		"outputPath": "./generated",
+	}, rawConfig)
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+)
+
+const (
+	DiagnosticsFormatText = "text"
+	DiagnosticsFormatJSON = "json"
+)
+
+const (
+	DiagnosticSeverityError = "error"
+)
+
+const (
+	DiagnosticCodeInvalidConfig = "invalid-config"
+	DiagnosticCodeCompileFailed = "compile-failed"
+	DiagnosticCodeOutOfDate     = "out-of-date"
+)
+
+// Diagnostic is a single problem of a run, as printed by the JSON diagnostics format.
+type Diagnostic struct {
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+
+	// Kind and Definition identify the failing Morphe definition, ie. "model" and "Person"
+	Kind       string `json:"kind,omitempty"`
+	Definition string `json:"definition,omitempty"`
+	// Field is the failing field, relation or enum entry of the definition
+	Field    string              `json:"field,omitempty"`
+	Location *DiagnosticLocation `json:"location,omitempty"`
+}
+
+// DiagnosticLocation is the file (and position) a diagnostic refers to, ie. a registry file or a generated file.
+type DiagnosticLocation struct {
+	File   string `json:"file"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+// DiagnosticsReport is written to stdout by the JSON diagnostics format. Files lists the generated (or checked) files
+// of a successful run.
+type DiagnosticsReport struct {
+	Diagnostics []Diagnostic           `json:"diagnostics"`
+	Files       []gofile.GeneratedFile `json:"files,omitempty"`
+}
+
+// diagnosticsReporter prints the outcome of a run, as text on stderr or as a DiagnosticsReport on stdout.
+type diagnosticsReporter struct {
+	format string
+}
+
+func (r diagnosticsReporter) isValid() bool {
+	return r.format == "" || r.format == DiagnosticsFormatText || r.format == DiagnosticsFormatJSON
+}
+
+func (r diagnosticsReporter) isJSON() bool {
+	return r.format == DiagnosticsFormatJSON
+}
+
+// fail prints the diagnostics of a failed run and exits. In the text format the text lines are printed to stderr instead.
+func (r diagnosticsReporter) fail(exitCode int, allDiagnostics []Diagnostic, textLines ...string) {
+	if r.isJSON() {
+		r.printReport(DiagnosticsReport{
+			Diagnostics: allDiagnostics,
+		})
+	} else {
+		for _, textLine := range textLines {
+			fmt.Fprintln(os.Stderr, textLine)
+		}
+	}
+	os.Exit(exitCode)
+}
+
+// failConfig fails the run with a single invalid configuration diagnostic.
+func (r diagnosticsReporter) failConfig(exitCode int, message string, textLines ...string) {
+	r.fail(exitCode, []Diagnostic{
+		{
+			Severity: DiagnosticSeverityError,
+			Code:     DiagnosticCodeInvalidConfig,
+			Message:  message,
+		},
+	}, textLines...)
+}
+
+// failConfigIssues fails the run with a diagnostic per config value that does not match the configSchema.
+func (r diagnosticsReporter) failConfigIssues(allIssues []configIssue) {
+	allDiagnostics := []Diagnostic{}
+	allTextLines := []string{
+		fmt.Sprintf("Error: invalid config, %d problems:", len(allIssues)),
+	}
+	for _, issue := range allIssues {
+		allDiagnostics = append(allDiagnostics, Diagnostic{
+			Severity: DiagnosticSeverityError,
+			Code:     DiagnosticCodeInvalidConfig,
+			Message:  issue.Message,
+			Field:    issue.Path,
+		})
+		allTextLines = append(allTextLines, "  "+issue.String())
+	}
+	r.fail(ErrInvalidConfig, allDiagnostics, allTextLines...)
+}
+
+// failCompile fails the run with a diagnostic per failing definition. Other errors are reported as a single diagnostic.
+func (r diagnosticsReporter) failCompile(compileErr error) {
+	if !r.isJSON() {
+		printCompileError(compileErr)
+		os.Exit(ErrCompileFailed)
+	}
+	r.fail(ErrCompileFailed, getCompileDiagnostics(compileErr))
+}
+
+// succeed prints the generated files of a successful run in the JSON format, the text format prints nothing.
+func (r diagnosticsReporter) succeed(allFiles []gofile.GeneratedFile) {
+	if !r.isJSON() {
+		return
+	}
+	r.printReport(DiagnosticsReport{
+		Diagnostics: []Diagnostic{},
+		Files:       allFiles,
+	})
+}
+
+func (r diagnosticsReporter) printReport(report DiagnosticsReport) {
+	if report.Diagnostics == nil {
+		report.Diagnostics = []Diagnostic{}
+	}
+	reportJSON, marshalErr := json.MarshalIndent(report, "", "  ")
+	if marshalErr != nil {
+		fmt.Fprintln(os.Stderr, "Error encoding diagnostics:", marshalErr)
+		return
+	}
+	fmt.Fprintln(os.Stdout, string(reportJSON))
+}
+
+// printCompileError prints every failing definition on its own line, prefixed with its "file:line:col" registry position
+// when known, or the single error of a failed compilation.
+func printCompileError(compileErr error) {
+	var compileErrs compile.CompileErrors
+	var definitionErr *compile.CompileError
+	if errors.As(compileErr, &compileErrs) {
+		fmt.Fprintf(os.Stderr, "Compilation failed with %d errors:\n", len(compileErrs))
+	} else if errors.As(compileErr, &definitionErr) {
+		fmt.Fprintln(os.Stderr, "Compilation failed:")
+		compileErrs = compile.CompileErrors{definitionErr}
+	} else {
+		fmt.Fprintln(os.Stderr, "Compilation failed:", compileErr)
+		return
+	}
+	for _, definitionErr := range compileErrs {
+		fmt.Fprintln(os.Stderr, definitionErr.Error())
+	}
+}
+
+func getCompileDiagnostics(compileErr error) []Diagnostic {
+	var compileErrs compile.CompileErrors
+	var definitionErr *compile.CompileError
+	if errors.As(compileErr, &definitionErr) && !errors.As(compileErr, &compileErrs) {
+		compileErrs = compile.CompileErrors{definitionErr}
+	}
+	if len(compileErrs) == 0 {
+		return []Diagnostic{
+			{
+				Severity: DiagnosticSeverityError,
+				Code:     DiagnosticCodeCompileFailed,
+				Message:  compileErr.Error(),
+			},
+		}
+	}
+
+	allDiagnostics := []Diagnostic{}
+	for _, definitionErr := range compileErrs {
+		diagnostic := Diagnostic{
+			Severity:   DiagnosticSeverityError,
+			Code:       DiagnosticCodeCompileFailed,
+			Message:    definitionErr.Message,
+			Kind:       string(definitionErr.Kind),
+			Definition: definitionErr.Definition,
+			Field:      definitionErr.Field,
+		}
+		if !definitionErr.Position.IsEmpty() {
+			diagnostic.Location = &DiagnosticLocation{
+				File:   definitionErr.Position.FilePath,
+				Line:   definitionErr.Position.Line,
+				Column: definitionErr.Position.Column,
+			}
+		}
+		allDiagnostics = append(allDiagnostics, diagnostic)
+	}
+	return allDiagnostics
+}
+
+// getCheckDiagnostics returns a diagnostic per generated file that differs from the output path.
+func getCheckDiagnostics(checkResult gofile.CheckResult) []Diagnostic {
+	allDiagnostics := []Diagnostic{}
+	addDiagnostic := func(filePath string, message string) {
+		allDiagnostics = append(allDiagnostics, Diagnostic{
+			Severity: DiagnosticSeverityError,
+			Code:     DiagnosticCodeOutOfDate,
+			Message:  message,
+			Location: &DiagnosticLocation{
+				File: filePath,
+			},
+		})
+	}
+	for _, fileDiff := range checkResult.Changed {
+		addDiagnostic(fileDiff.FilePath, "generated file is out of date")
+	}
+	for _, filePath := range checkResult.Missing {
+		addDiagnostic(filePath, "generated file is missing")
+	}
+	for _, filePath := range checkResult.Extra {
+		addDiagnostic(filePath, "generated file is no longer produced")
+	}
+	return allDiagnostics
+}
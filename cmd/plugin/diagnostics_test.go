@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DiagnosticsTestSuite struct {
+	suite.Suite
+
+	registryDirPath string
+	outputDirPath   string
+}
+
+func TestDiagnosticsTestSuite(t *testing.T) {
+	suite.Run(t, new(DiagnosticsTestSuite))
+}
+
+func (suite *DiagnosticsTestSuite) SetupTest() {
+	registryDirPath, absErr := filepath.Abs(filepath.Join("..", "..", "testdata", "registry", "minimal"))
+	suite.Require().NoError(absErr)
+	suite.registryDirPath = registryDirPath
+	suite.outputDirPath = suite.T().TempDir()
+}
+
+// getConfigArg returns the inline JSON config of a run of the registry, with the extra top-level keys.
+func (suite *DiagnosticsTestSuite) getConfigArg(registryDirPath string, allExtraKeys map[string]any) string {
+	rawConfig := map[string]any{
+		"inputPath":  registryDirPath,
+		"outputPath": suite.outputDirPath,
+		"config": map[string]any{
+			"models":     map[string]any{"packagePath": "github.com/kalo-build/dummy/models"},
+			"enums":      map[string]any{"packagePath": "github.com/kalo-build/dummy/enums"},
+			"structures": map[string]any{"packagePath": "github.com/kalo-build/dummy/structures"},
+			"entities":   map[string]any{"packagePath": "github.com/kalo-build/dummy/entities"},
+		},
+	}
+	for key, value := range allExtraKeys {
+		rawConfig[key] = value
+	}
+	configJSON, marshalErr := json.Marshal(rawConfig)
+	suite.Require().NoError(marshalErr)
+	return string(configJSON)
+}
+
+func (suite *DiagnosticsTestSuite) getReport(run pluginRun) DiagnosticsReport {
+	var report DiagnosticsReport
+	suite.Require().NoError(json.Unmarshal([]byte(run.Stdout), &report), run.Stdout)
+	return report
+}
+
+func (suite *DiagnosticsTestSuite) TestFailConfig_JSON() {
+	run := runPlugin(suite.T(), "", nil, "--diagnostics=json")
+
+	suite.Equal(ErrMissingConfig, run.ExitCode)
+	suite.JSONEq(`{
+		"diagnostics": [
+			{
+				"severity": "error",
+				"code": "invalid-config",
+				"message": "config is required"
+			}
+		]
+	}`, run.Stdout)
+}
+
+func (suite *DiagnosticsTestSuite) TestFailConfig_Text() {
+	run := runPlugin(suite.T(), "", nil, `{"outputPath":"./generated"}`)
+
+	suite.Equal(ErrInputPathRequired, run.ExitCode)
+	suite.Empty(run.Stdout)
+	suite.Contains(run.Stderr, "Error: Input path is required")
+}
+
+func (suite *DiagnosticsTestSuite) TestFailConfig_PackagePathRequired() {
+	configArg := `{"inputPath":"./registry","outputPath":"./generated","diagnostics":"json"}`
+
+	run := runPlugin(suite.T(), "", nil, configArg)
+
+	suite.Equal(ErrPackagePathRequired, run.ExitCode)
+	suite.JSONEq(`{
+		"diagnostics": [
+			{
+				"severity": "error",
+				"code": "invalid-config",
+				"message": "models package path is required"
+			}
+		]
+	}`, run.Stdout)
+}
+
+func (suite *DiagnosticsTestSuite) TestFailConfigIssues_JSON() {
+	configArg := suite.getConfigArg(suite.registryDirPath, map[string]any{
+		"verbos": true,
+	})
+
+	run := runPlugin(suite.T(), "", nil, "--diagnostics=json", configArg)
+
+	suite.Equal(ErrInvalidConfig, run.ExitCode)
+	suite.JSONEq(`{
+		"diagnostics": [
+			{
+				"severity": "error",
+				"code": "invalid-config",
+				"message": "unknown key",
+				"field": "verbos"
+			}
+		]
+	}`, run.Stdout)
+}
+
+func (suite *DiagnosticsTestSuite) TestFailConfigIssues_Text() {
+	configArg := suite.getConfigArg(suite.registryDirPath, map[string]any{
+		"verbos": true,
+	})
+
+	run := runPlugin(suite.T(), "", nil, configArg)
+
+	suite.Equal(ErrInvalidConfig, run.ExitCode)
+	suite.Contains(run.Stderr, "Error: invalid config, 1 problems:\n  verbos: unknown key\n")
+}
+
+func (suite *DiagnosticsTestSuite) TestFailCompile_JSON() {
+	registryDirPath := suite.T().TempDir()
+	modelsDirPath := filepath.Join(registryDirPath, "models")
+	suite.Require().NoError(os.MkdirAll(modelsDirPath, 0755))
+	modelContents := "name: Person\n" +
+		"fields:\n" +
+		"  ID:\n" +
+		"    type: AutoIncrement\n" +
+		"identifiers:\n" +
+		"  primary: ID\n" +
+		"related:\n" +
+		"  Team:\n" +
+		"    type: ForOne\n"
+	modelPath := filepath.Join(modelsDirPath, "person.mod")
+	suite.Require().NoError(os.WriteFile(modelPath, []byte(modelContents), 0644))
+
+	run := runPlugin(suite.T(), "", nil, "--diagnostics=json", suite.getConfigArg(registryDirPath, nil))
+
+	suite.Equal(ErrCompileFailed, run.ExitCode)
+	report := suite.getReport(run)
+	suite.Empty(report.Files)
+	suite.Require().Len(report.Diagnostics, 1)
+	diagnostic := report.Diagnostics[0]
+	suite.Equal(DiagnosticSeverityError, diagnostic.Severity)
+	suite.Equal(DiagnosticCodeCompileFailed, diagnostic.Code)
+	suite.Equal("model", diagnostic.Kind)
+	suite.Equal("Person", diagnostic.Definition)
+	suite.Equal("Team", diagnostic.Field)
+	suite.NotEmpty(diagnostic.Message)
+	suite.Equal(&DiagnosticLocation{
+		File:   modelPath,
+		Line:   8,
+		Column: 3,
+	}, diagnostic.Location)
+}
+
+func (suite *DiagnosticsTestSuite) TestSucceed_JSON() {
+	run := runPlugin(suite.T(), "", nil, "--diagnostics=json", suite.getConfigArg(suite.registryDirPath, nil))
+
+	suite.Equal(0, run.ExitCode, run.Stderr)
+	report := suite.getReport(run)
+	suite.Empty(report.Diagnostics)
+	suite.NotNil(report.Diagnostics)
+	suite.NotEmpty(report.Files)
+	for _, generatedFile := range report.Files {
+		suite.FileExists(generatedFile.Path)
+		suite.Regexp(`^sha256:[0-9a-f]{64}$`, generatedFile.Hash)
+	}
+}
+
+func (suite *DiagnosticsTestSuite) TestCheck() {
+	configArg := suite.getConfigArg(suite.registryDirPath, nil)
+	checkConfigArg := suite.getConfigArg(suite.registryDirPath, map[string]any{
+		"check": true,
+	})
+	suite.Require().Equal(0, runPlugin(suite.T(), "", nil, configArg).ExitCode)
+
+	cleanRun := runPlugin(suite.T(), "", nil, checkConfigArg)
+
+	suite.Equal(0, cleanRun.ExitCode, cleanRun.Stderr)
+
+	personPath := filepath.Join(suite.outputDirPath, "models", "person.go")
+	suite.Require().NoError(os.WriteFile(personPath, []byte("package models\n"), 0644))
+
+	textRun := runPlugin(suite.T(), "", nil, checkConfigArg)
+
+	suite.Equal(ErrCheckFailed, textRun.ExitCode)
+	suite.Contains(textRun.Stdout, "models/person.go")
+	suite.Contains(textRun.Stderr, "Check failed: generated files are out of date")
+
+	jsonRun := runPlugin(suite.T(), "", nil, "--diagnostics=json", checkConfigArg)
+
+	suite.Equal(ErrCheckFailed, jsonRun.ExitCode)
+	suite.Equal([]Diagnostic{
+		{
+			Severity: DiagnosticSeverityError,
+			Code:     DiagnosticCodeOutOfDate,
+			Message:  "generated file is out of date",
+			Location: &DiagnosticLocation{
+				File: personPath,
+			},
+		},
+	}, suite.getReport(jsonRun).Diagnostics)
+}
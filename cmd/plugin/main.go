@@ -1,42 +1,16 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile"
-	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
 )
 
-type CompileConfigEntryStruct struct {
-	PackagePath  string `json:"PackagePath"`
-	ReceiverName string `json:"ReceiverName"`
-}
-
-type CompileConfigEntryEnum struct {
-	PackagePath string `json:"PackagePath"`
-}
-
-type CompileConfigEntries struct {
-	// FieldCasing applies to all sections (models, structures, entities).
-	// Valid values: "camel", "snake", "pascal", or "" (none / no JSON tags).
-	FieldCasing string `json:"fieldCasing,omitempty"`
-
-	Models     CompileConfigEntryStruct `json:"models"`
-	Enums      CompileConfigEntryEnum   `json:"enums"`
-	Structures CompileConfigEntryStruct `json:"structures"`
-	Entities   CompileConfigEntryStruct `json:"entities"`
-}
-
-type CompileConfig struct {
-	InputPath  string               `json:"inputPath"`
-	OutputPath string               `json:"outputPath"`
-	Config     CompileConfigEntries `json:"config"`
-	Verbose    bool                 `json:"verbose,omitempty"`
-}
-
 const (
 	ErrMissingConfig       = 3
 	ErrInvalidConfig       = 4
@@ -44,58 +18,104 @@ const (
 	ErrOutputPathRequired  = 13
 	ErrPackagePathRequired = 14
 	ErrCompileFailed       = 1
+	ErrCheckFailed         = 2
 )
 
+// infoOutput receives the verbose info messages, stderr when stdout holds the JSON diagnostics
+var infoOutput io.Writer = os.Stdout
+
 // logInfo prints info messages only when verbose mode is enabled
 func logInfo(verbose bool, format string, args ...interface{}) {
 	if verbose {
-		fmt.Fprintf(os.Stdout, format+"\n", args...)
+		fmt.Fprintf(infoOutput, format+"\n", args...)
 	}
 }
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Fprintln(os.Stderr, "Usage: plugin-morphe-go-types <config>")
-		fmt.Fprintln(os.Stderr, "  config: JSON string with inputPath, outputPath, and optional config parameters")
-		os.Exit(ErrMissingConfig)
+	// Bad flags exit with ErrInvalidConfig, the flag package would exit with 2 (ErrCheckFailed)
+	flags := flag.NewFlagSet("plugin-morphe-go-types", flag.ContinueOnError)
+	diagnosticsFormat := flags.String("diagnostics", "", "output format of the run: \"text\" or \"json\" (diagnostics and generated files on stdout)")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: plugin-morphe-go-types [--diagnostics=text|json] <config>")
+		fmt.Fprintln(os.Stderr, "  config: JSON string with inputPath, outputPath, and optional config parameters,")
+		fmt.Fprintln(os.Stderr, "          the path to a YAML or JSON config file, or \"-\" to read it from stdin")
+		fmt.Fprintf(os.Stderr, "  %s and %s override the input and output paths\n", EnvInputPath, EnvOutputPath)
+	}
+	parseErr := flags.Parse(os.Args[1:])
+	if errors.Is(parseErr, flag.ErrHelp) {
+		os.Exit(0)
+	}
+
+	reporter := diagnosticsReporter{
+		format: *diagnosticsFormat,
+	}
+	if parseErr != nil {
+		// The flag package already printed the error and usage to stderr
+		reporter.failConfig(ErrInvalidConfig, parseErr.Error())
+	}
+	if flags.NArg() < 1 {
+		if !reporter.isJSON() {
+			flags.Usage()
+		}
+		reporter.failConfig(ErrMissingConfig, "config is required")
 	}
 
-	rawConfig := os.Args[1]
-	var compileConfig CompileConfig
-	if err := json.Unmarshal([]byte(rawConfig), &compileConfig); err != nil {
-		fmt.Fprintln(os.Stderr, "Error parsing config JSON:", err)
-		fmt.Fprintln(os.Stderr, "Expected format: {\"inputPath\":\"...\",\"outputPath\":\"...\",\"config\":{...},\"verbose\":false}")
+	rawConfig, readErr := readRawConfig(flags.Arg(0), os.Stdin)
+	if readErr != nil {
+		reporter.failConfig(ErrInvalidConfig, readErr.Error(),
+			fmt.Sprint("Error: ", readErr),
+			"Expected a JSON object ({\"inputPath\":\"...\",\"outputPath\":\"...\",\"config\":{...}}), the path to a YAML or JSON config file, or \"-\" for stdin",
+		)
+	}
+	applyEnvOverrides(rawConfig)
+
+	if configFormat, isString := rawConfig["diagnostics"].(string); isString && reporter.format == "" {
+		reporter.format = configFormat
+	}
+	if !reporter.isValid() {
+		fmt.Fprintf(os.Stderr, "Error: invalid diagnostics format %q, must be one of: text, json\n", reporter.format)
 		os.Exit(ErrInvalidConfig)
 	}
 
+	configSchema, schemaErr := loadConfigSchema()
+	if schemaErr != nil {
+		reporter.failConfig(ErrInvalidConfig, schemaErr.Error(), fmt.Sprint("Error: ", schemaErr))
+	}
+	if allIssues := validateRawConfig(rawConfig, configSchema); len(allIssues) > 0 {
+		reporter.failConfigIssues(allIssues)
+	}
+
+	compileConfig, decodeErr := decodeCompileConfig(rawConfig)
+	if decodeErr != nil {
+		reporter.failConfig(ErrInvalidConfig, decodeErr.Error(), fmt.Sprint("Error: ", decodeErr))
+	}
+
+	if reporter.isJSON() {
+		infoOutput = os.Stderr
+	}
+
 	if compileConfig.InputPath == "" {
-		fmt.Fprintln(os.Stderr, "Error: Input path is required")
-		os.Exit(ErrInputPathRequired)
+		reporter.failConfig(ErrInputPathRequired, "input path is required", "Error: Input path is required")
 	}
 
 	if compileConfig.OutputPath == "" {
-		fmt.Fprintln(os.Stderr, "Error: Output path is required")
-		os.Exit(ErrOutputPathRequired)
+		reporter.failConfig(ErrOutputPathRequired, "output path is required", "Error: Output path is required")
 	}
 
 	if compileConfig.Config.Models.PackagePath == "" {
-		fmt.Fprintln(os.Stderr, "Error: Models package path is required")
-		os.Exit(ErrPackagePathRequired)
+		reporter.failConfig(ErrPackagePathRequired, "models package path is required", "Error: Models package path is required")
 	}
 
 	if compileConfig.Config.Enums.PackagePath == "" {
-		fmt.Fprintln(os.Stderr, "Error: Enums package path is required")
-		os.Exit(ErrPackagePathRequired)
+		reporter.failConfig(ErrPackagePathRequired, "enums package path is required", "Error: Enums package path is required")
 	}
 
 	if compileConfig.Config.Structures.PackagePath == "" {
-		fmt.Fprintln(os.Stderr, "Error: Structures package path is required")
-		os.Exit(ErrPackagePathRequired)
+		reporter.failConfig(ErrPackagePathRequired, "structures package path is required", "Error: Structures package path is required")
 	}
 
 	if compileConfig.Config.Entities.PackagePath == "" {
-		fmt.Fprintln(os.Stderr, "Error: Entities package path is required")
-		os.Exit(ErrPackagePathRequired)
+		reporter.failConfig(ErrPackagePathRequired, "entities package path is required", "Error: Entities package path is required")
 	}
 
 	inputAbs, err := filepath.Abs(compileConfig.InputPath)
@@ -118,41 +138,41 @@ func main() {
 		compileConfig.OutputPath,
 	)
 
-	logInfo(compileConfig.Verbose, "Setting package paths...")
-	// Set the package paths (mandatory)
-	morpheConfig.MorpheModelsConfig.Package.Path = compileConfig.Config.Models.PackagePath
-	morpheConfig.MorpheEnumsConfig.Package.Path = compileConfig.Config.Enums.PackagePath
-	morpheConfig.MorpheStructuresConfig.Package.Path = compileConfig.Config.Structures.PackagePath
-	morpheConfig.MorpheEntitiesConfig.Package.Path = compileConfig.Config.Entities.PackagePath
-
-	logInfo(compileConfig.Verbose, "Setting receiver names...")
-	// Set the receiver names (optional)
-	if compileConfig.Config.Models.ReceiverName != "" {
-		morpheConfig.MorpheModelsConfig.ReceiverName = compileConfig.Config.Models.ReceiverName
-	}
-	if compileConfig.Config.Structures.ReceiverName != "" {
-		morpheConfig.MorpheStructuresConfig.ReceiverName = compileConfig.Config.Structures.ReceiverName
-	}
-	if compileConfig.Config.Entities.ReceiverName != "" {
-		morpheConfig.MorpheEntitiesConfig.ReceiverName = compileConfig.Config.Entities.ReceiverName
+	logInfo(compileConfig.Verbose, "Applying config...")
+	// Set the package paths (mandatory) and the optional generation options
+	compileConfig.Config.ApplyTo(&morpheConfig)
+	if configErr := morpheConfig.MorpheConfig.Validate(); configErr != nil {
+		reporter.failConfig(ErrInvalidConfig, configErr.Error(), fmt.Sprint("Error: ", configErr))
 	}
 
-	// Set field casing for JSON struct tags (applies to all sections)
-	if compileConfig.Config.FieldCasing != "" {
-		casing := cfg.Casing(compileConfig.Config.FieldCasing)
-		logInfo(compileConfig.Verbose, "Setting field casing to: %s", casing)
-		morpheConfig.MorpheModelsConfig.FieldCasing = casing
-		morpheConfig.MorpheStructuresConfig.FieldCasing = casing
-		morpheConfig.MorpheEntitiesConfig.FieldCasing = casing
+	// Report every failing definition of the registry in one run
+	morpheConfig.AggregateErrors = !compileConfig.FailFast
+
+	if compileConfig.Check {
+		logInfo(compileConfig.Verbose, "Checking generated files against: '%s'", compileConfig.OutputPath)
+		checkResult, checkErr := compile.CheckMorpheToGo(morpheConfig)
+		if checkErr != nil {
+			reporter.failCompile(checkErr)
+		}
+		if !checkResult.IsClean() {
+			if !reporter.isJSON() {
+				fmt.Fprint(os.Stdout, checkResult.String())
+			}
+			reporter.fail(ErrCheckFailed, getCheckDiagnostics(checkResult), "Check failed: generated files are out of date")
+		}
+
+		logInfo(compileConfig.Verbose, "Generated files are up to date")
+		reporter.succeed(compile.GetAllGeneratedFiles(morpheConfig))
+		os.Exit(0)
 	}
 
 	logInfo(compileConfig.Verbose, "Starting compilation process...")
 	compileErr := compile.MorpheToGo(morpheConfig)
 	if compileErr != nil {
-		fmt.Fprintln(os.Stderr, "Compilation failed:", compileErr)
-		os.Exit(ErrCompileFailed)
+		reporter.failCompile(compileErr)
 	}
 
 	logInfo(compileConfig.Verbose, "Compilation completed successfully")
+	reporter.succeed(compile.GetAllGeneratedFiles(morpheConfig))
 	os.Exit(0)
 }
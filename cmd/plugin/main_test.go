@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// envRunMain makes the test binary run main with its arguments instead of the tests, see runPlugin.
+const envRunMain = "PLUGIN_MORPHE_GO_STRUCT_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(envRunMain) != "" {
+		main()
+	}
+	os.Exit(m.Run())
+}
+
+// pluginRun is the outcome of a plugin process.
+type pluginRun struct {
+	ExitCode int
+	Stdout   string
+	Stderr   string
+}
+
+// runPlugin runs main in a child process of the test binary, with the arguments, stdin contents and extra environment.
+func runPlugin(t *testing.T, stdin string, allEnv []string, allArgs ...string) pluginRun {
+	cmd := exec.Command(os.Args[0], allArgs...)
+	cmd.Env = append(append(os.Environ(), envRunMain+"=1", EnvInputPath+"=", EnvOutputPath+"="), allEnv...)
+	cmd.Stdin = bytes.NewBufferString(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	run := pluginRun{}
+	runErr := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(runErr, &exitErr) {
+		run.ExitCode = exitErr.ExitCode()
+	} else if runErr != nil {
+		t.Fatal(runErr)
+	}
+	run.Stdout = stdout.String()
+	run.Stderr = stderr.String()
+	return run
+}
+
+type MainTestSuite struct {
+	suite.Suite
+}
+
+func TestMainTestSuite(t *testing.T) {
+	suite.Run(t, new(MainTestSuite))
+}
+
+func (suite *MainTestSuite) TestMain_InvalidFlag() {
+	run := runPlugin(suite.T(), "", nil, "--unknown-flag", "{}")
+
+	suite.Equal(ErrInvalidConfig, run.ExitCode)
+	suite.NotEqual(ErrCheckFailed, run.ExitCode)
+	suite.Contains(run.Stderr, "flag provided but not defined: -unknown-flag")
+}
+
+func (suite *MainTestSuite) TestMain_Help() {
+	run := runPlugin(suite.T(), "", nil, "--help")
+
+	suite.Equal(0, run.ExitCode)
+	suite.Contains(run.Stderr, "Usage: plugin-morphe-go-types")
+}
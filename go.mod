@@ -7,7 +7,9 @@ require (
 	github.com/kalo-build/go v0.0.0-20250329083200-af53fba2b8e5
 	github.com/kalo-build/go-util v0.0.0-20260312091936-ee39e432fcc2
 	github.com/kalo-build/morphe-go v0.0.0-20260315110949-bffc845469fb
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/stretchr/testify v1.9.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -15,7 +17,5 @@ require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/gertd/go-pluralize v0.2.1 // indirect
 	github.com/gobeam/stringy v0.0.7 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,35 @@
+package testutils
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// RunGoTests writes the files (module relative path -> contents) into a temporary Go module without dependencies and
+// runs its tests, ie. round trip tests of generated code. The test is skipped if the go tool is not available.
+func RunGoTests(t *testing.T, allFiles map[string][]byte) (string, error) {
+	goPath, lookErr := exec.LookPath("go")
+	if lookErr != nil {
+		t.Skip("go tool not available:", lookErr)
+	}
+
+	moduleDirPath := t.TempDir()
+	allFiles["go.mod"] = []byte("module generated\n\ngo 1.21\n")
+	for filePath, fileContents := range allFiles {
+		fullPath := filepath.Join(moduleDirPath, filepath.FromSlash(filePath))
+		if mkdirErr := os.MkdirAll(filepath.Dir(fullPath), 0755); mkdirErr != nil {
+			return "", mkdirErr
+		}
+		if writeErr := os.WriteFile(fullPath, fileContents, 0644); writeErr != nil {
+			return "", writeErr
+		}
+	}
+
+	testCmd := exec.Command(goPath, "test", "./...")
+	testCmd.Dir = moduleDirPath
+	testCmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+	output, testErr := testCmd.CombinedOutput()
+	return string(output), testErr
+}
@@ -0,0 +1,41 @@
+package cfg
+
+// FieldOrder represents the order of the compiled struct fields
+type FieldOrder string
+
+const (
+	// FieldOrderSorted sorts the direct fields and the related fields by name
+	FieldOrderSorted FieldOrder = ""
+	// FieldOrderDeclaration keeps the order of the YAML source: identifier fields, then the remaining fields, then relations
+	FieldOrderDeclaration FieldOrder = "declaration"
+)
+
+// IsValid returns true if the field order is a valid option
+func (o FieldOrder) IsValid() bool {
+	switch o {
+	case FieldOrderSorted, FieldOrderDeclaration:
+		return true
+	default:
+		return false
+	}
+}
+
+// DeclarationOrder lists the identifiers, fields and relations of a definition in the order of its YAML source
+type DeclarationOrder struct {
+	Identifiers []string
+	Fields      []string
+	Related     []string
+}
+
+// DeclarationOrders holds the declaration order of every definition by name, it is required by FieldOrderDeclaration.
+// Definitions without a declaration order (ie. registries built in code) keep the sorted order.
+type DeclarationOrders struct {
+	Models     map[string]DeclarationOrder
+	Entities   map[string]DeclarationOrder
+	Structures map[string]DeclarationOrder
+}
+
+// IsEmpty returns true if no declaration order has been loaded
+func (orders DeclarationOrders) IsEmpty() bool {
+	return len(orders.Models) == 0 && len(orders.Entities) == 0 && len(orders.Structures) == 0
+}
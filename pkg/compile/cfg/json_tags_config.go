@@ -0,0 +1,88 @@
+package cfg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JSONOmit is the JSON tag option added to optional (pointer) fields
+type JSONOmit string
+
+const (
+	// JSONOmitNone always marshals optional fields, nil as null
+	JSONOmitNone JSONOmit = ""
+	// JSONOmitEmpty adds ",omitempty"
+	JSONOmitEmpty JSONOmit = "omitempty"
+	// JSONOmitZero adds ",omitzero" (Go 1.24+)
+	JSONOmitZero JSONOmit = "omitzero"
+)
+
+// JSONRelations controls how fields holding related structs (ie. "Company *Company") are marshalled
+type JSONRelations string
+
+const (
+	// JSONRelationsInclude marshals related structs like any other field
+	JSONRelationsInclude JSONRelations = ""
+	// JSONRelationsOmitEmpty only marshals related structs that are set
+	JSONRelationsOmitEmpty JSONRelations = "omitempty"
+	// JSONRelationsExclude never marshals related structs (`json:"-"`), only the related IDs
+	JSONRelationsExclude JSONRelations = "exclude"
+)
+
+// JSONTagsConfig controls the options of the JSON struct tags. The tag names follow the FieldCasing of the section,
+// or the Go field name if FieldCasing is empty.
+type JSONTagsConfig struct {
+	// OmitOptional is added to all pointer fields, ie. optional fields and related structs.
+	// Valid values: "omitempty", "omitzero", or "" (none)
+	OmitOptional JSONOmit
+
+	// Relations controls the fields holding related structs of models and entities.
+	// Valid values: "omitempty", "exclude", or "" (include)
+	Relations JSONRelations
+
+	// Fields overrides the JSON tag of single fields, keyed by "Struct.Field" (ie. "Person.ID")
+	Fields map[string]JSONFieldOverride
+}
+
+// JSONFieldOverride replaces the JSON tag name and options of a single field.
+type JSONFieldOverride struct {
+	// Name replaces the cased field name, "-" excludes the field
+	Name string
+
+	// String marshals the value as a JSON string (",string"), ie. for int64 IDs read by JavaScript clients
+	String bool
+}
+
+func (config JSONTagsConfig) Validate() error {
+	switch config.OmitOptional {
+	case JSONOmitNone, JSONOmitEmpty, JSONOmitZero:
+	default:
+		return fmt.Errorf("invalid json omitOptional value %q, must be one of: omitempty, omitzero, or empty", config.OmitOptional)
+	}
+	switch config.Relations {
+	case JSONRelationsInclude, JSONRelationsOmitEmpty, JSONRelationsExclude:
+	default:
+		return fmt.Errorf("invalid json relations value %q, must be one of: omitempty, exclude, or empty", config.Relations)
+	}
+	for fieldPath, override := range config.Fields {
+		pathParts := strings.Split(fieldPath, ".")
+		if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] == "" {
+			return fmt.Errorf("invalid json field '%s', must be 'Struct.Field'", fieldPath)
+		}
+		if override.Name == "" && !override.String {
+			return fmt.Errorf("json field '%s' must override the name or set string", fieldPath)
+		}
+	}
+	return nil
+}
+
+// IsEnabled returns true if any JSON tag option is configured.
+func (config JSONTagsConfig) IsEnabled() bool {
+	return config.OmitOptional != JSONOmitNone || config.Relations != JSONRelationsInclude || len(config.Fields) > 0
+}
+
+// GetFieldOverride returns the JSON tag override of a struct field.
+func (config JSONTagsConfig) GetFieldOverride(structName string, fieldName string) (JSONFieldOverride, bool) {
+	override, hasOverride := config.Fields[structName+"."+fieldName]
+	return override, hasOverride
+}
@@ -5,6 +5,11 @@ type MorpheConfig struct {
 	MorpheStructuresConfig
 	MorpheEnumsConfig
 	MorpheEntitiesConfig
+	MorpheSupportConfig
+	MorpheTypeOverridesConfig
+
+	// DeclarationOrders is the YAML source order of all definitions, loaded by MorpheToGo if any section uses FieldOrderDeclaration
+	DeclarationOrders DeclarationOrders
 }
 
 func (config MorpheConfig) Validate() error {
@@ -28,5 +33,15 @@ func (config MorpheConfig) Validate() error {
 		return entitiesErr
 	}
 
+	supportErr := config.MorpheSupportConfig.Validate()
+	if supportErr != nil {
+		return supportErr
+	}
+
+	typeOverridesErr := config.MorpheTypeOverridesConfig.Validate()
+	if typeOverridesErr != nil {
+		return typeOverridesErr
+	}
+
 	return nil
 }
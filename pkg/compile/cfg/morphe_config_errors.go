@@ -5,3 +5,4 @@ import "errors"
 var ErrNoPackagePath = errors.New("package path cannot be empty")
 var ErrNoPackageName = errors.New("package name cannot be empty")
 var ErrNoReceiverName = errors.New("method receiver name cannot be empty")
+var ErrNoTypeOverrideName = errors.New("type override name cannot be empty")
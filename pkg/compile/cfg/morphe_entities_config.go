@@ -15,6 +15,39 @@ type MorpheEntitiesConfig struct {
 	// FieldCasing specifies the casing for serialization (JSON struct tags). Empty means no tags.
 	// Valid values: "camel", "snake", "pascal", or "" (none)
 	FieldCasing Casing
+
+	// DocComments renders doc comments on the structs and their fields, taken from the "description" keys of the YAML source
+	// or synthesized from the schema (ie. "// HasMany Person" on relation fields)
+	DocComments bool
+
+	// FieldOrder specifies the order of the struct fields, sorted by name by default.
+	// Valid values: "declaration" (YAML source order), or "" (sorted)
+	FieldOrder FieldOrder
+
+	// JSONTags controls the options of the JSON struct tags (omitempty, related structs and per-field overrides)
+	JSONTags JSONTagsConfig
+
+	// ORMTags enables db, gorm and bun struct tags
+	ORMTags ORMTagsConfig
+
+	// ValidateTags enables go-playground/validator struct tags (ie. `validate:"required,uuid"`)
+	ValidateTags bool
+
+	// ValidateMethods generates a dependency-free Validate() error method per struct, which returns ValidationErrors
+	// (declared next to the structs) listing the path of every invalid field
+	ValidateMethods bool
+
+	// ModelMappers generates a <Entity>FromModel function per entity, which copies the fields of the root model by following
+	// the entity field paths through the loaded relations (ie. "Person.ContactInfo.Email")
+	ModelMappers bool
+
+	// CloneMethods generates a Clone() method per entity returning a deep copy, including the related entities.
+	// Cyclic relations are cloned once, see MorpheModelsConfig.CloneMethods
+	CloneMethods bool
+
+	// DiffMethods generates Equal(other) bool and Diff(other) []FieldChange methods per entity, related entities are
+	// compared by their related ID fields
+	DiffMethods bool
 }
 
 func (config MorpheEntitiesConfig) Validate() error {
@@ -30,6 +63,17 @@ func (config MorpheEntitiesConfig) Validate() error {
 	if !config.FieldCasing.IsValid() {
 		return fmt.Errorf("entities: invalid fieldCasing value %q, must be one of: camel, snake, pascal, or empty", config.FieldCasing)
 	}
+	if !config.FieldOrder.IsValid() {
+		return fmt.Errorf("entities: invalid fieldOrder value %q, must be one of: declaration, or empty", config.FieldOrder)
+	}
+	jsonTagsErr := config.JSONTags.Validate()
+	if jsonTagsErr != nil {
+		return fmt.Errorf("entities: %w", jsonTagsErr)
+	}
+	ormTagsErr := config.ORMTags.Validate()
+	if ormTagsErr != nil {
+		return fmt.Errorf("entities: %w", ormTagsErr)
+	}
 	return nil
 }
 
@@ -8,6 +8,20 @@ import (
 
 type MorpheEnumsConfig struct {
 	Package godef.Package
+
+	// TextMarshalling generates MarshalText and UnmarshalText methods (encoding.TextMarshaler, encoding.TextUnmarshaler).
+	// The text is the underlying value like in JSON and SQL, ie. "2" for a numeric enum, not its entry name.
+	TextMarshalling bool
+
+	// JSONMarshalling generates MarshalJSON and UnmarshalJSON methods (json.Marshaler, json.Unmarshaler)
+	JSONMarshalling bool
+
+	// SQLMarshalling generates Value and Scan methods (driver.Valuer, sql.Scanner)
+	SQLMarshalling bool
+
+	// DocComments renders doc comments on the enums and their entries, taken from the "description" key of the YAML source
+	// or synthesized from the schema
+	DocComments bool
 }
 
 func (config MorpheEnumsConfig) Validate() error {
@@ -19,3 +33,8 @@ func (config MorpheEnumsConfig) Validate() error {
 	}
 	return nil
 }
+
+// HasMarshalling returns true if any marshalling methods are generated, which all reject undeclared values with a typed error.
+func (config MorpheEnumsConfig) HasMarshalling() bool {
+	return config.TextMarshalling || config.JSONMarshalling || config.SQLMarshalling
+}
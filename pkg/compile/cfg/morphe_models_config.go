@@ -15,6 +15,47 @@ type MorpheModelsConfig struct {
 	// FieldCasing specifies the casing for serialization (JSON struct tags). Empty means no tags.
 	// Valid values: "camel", "snake", "pascal", or "" (none)
 	FieldCasing Casing
+
+	// DocComments renders doc comments on the structs and their fields, taken from the "description" keys of the YAML source
+	// or synthesized from the schema (ie. "// HasMany Person" on relation fields)
+	DocComments bool
+
+	// FieldOrder specifies the order of the struct fields, sorted by name by default.
+	// Valid values: "declaration" (YAML source order), or "" (sorted)
+	FieldOrder FieldOrder
+
+	// JSONTags controls the options of the JSON struct tags (omitempty, related structs and per-field overrides)
+	JSONTags JSONTagsConfig
+
+	// ORMTags enables db, gorm and bun struct tags
+	ORMTags ORMTagsConfig
+
+	// ValidateTags enables go-playground/validator struct tags (ie. `validate:"required,uuid"`)
+	ValidateTags bool
+
+	// ValidateMethods generates a dependency-free Validate() error method per struct, which returns ValidationErrors
+	// (declared next to the structs) listing the path of every invalid field
+	ValidateMethods bool
+
+	// TypedIDs generates a named ID type per model (ie. "type PersonID uint") for the primary identifier field,
+	// which is also used by all related ID fields and identifier structs referencing the model
+	TypedIDs bool
+
+	// TypedPolyRelations generates a closed type enum (ie. "CommentableTypePerson") and a marker interface per polymorphic
+	// For* relation, as well as typed helpers (ie. "SetCommentable(Person)" and "CommentableAsPerson()")
+	TypedPolyRelations bool
+
+	// Constructors generates a New<Model> function taking all required fields and required ForOne relation IDs, as well as
+	// chainable With<Field> methods for the optional ones (ie. "NewPerson(firstName, companyID).WithNickname(nickname)")
+	Constructors bool
+
+	// CloneMethods generates a Clone() method per model returning a deep copy. Optional fields, related IDs and related models
+	// are copied, while shared and cyclic references (ie. Company <-> Person) stay shared in the copy
+	CloneMethods bool
+
+	// DiffMethods generates Equal(other) bool and Diff(other) []FieldChange methods per model. Relations are compared by
+	// their related ID fields, optional fields by the values they point to and time fields with time.Time.Equal
+	DiffMethods bool
 }
 
 func (config MorpheModelsConfig) Validate() error {
@@ -30,6 +71,17 @@ func (config MorpheModelsConfig) Validate() error {
 	if !config.FieldCasing.IsValid() {
 		return fmt.Errorf("models: invalid fieldCasing value %q, must be one of: camel, snake, pascal, or empty", config.FieldCasing)
 	}
+	if !config.FieldOrder.IsValid() {
+		return fmt.Errorf("models: invalid fieldOrder value %q, must be one of: declaration, or empty", config.FieldOrder)
+	}
+	jsonTagsErr := config.JSONTags.Validate()
+	if jsonTagsErr != nil {
+		return fmt.Errorf("models: %w", jsonTagsErr)
+	}
+	ormTagsErr := config.ORMTags.Validate()
+	if ormTagsErr != nil {
+		return fmt.Errorf("models: %w", ormTagsErr)
+	}
 	return nil
 }
 
@@ -15,6 +15,27 @@ type MorpheStructuresConfig struct {
 	// FieldCasing specifies the casing for serialization (JSON struct tags). Empty means no tags.
 	// Valid values: "camel", "snake", "pascal", or "" (none)
 	FieldCasing Casing
+
+	// DocComments renders doc comments on the structs and their fields, taken from the "description" keys of the YAML source
+	// or synthesized from the schema (ie. "// HasMany Person" on relation fields)
+	DocComments bool
+
+	// FieldOrder specifies the order of the struct fields, sorted by name by default.
+	// Valid values: "declaration" (YAML source order), or "" (sorted)
+	FieldOrder FieldOrder
+
+	// JSONTags controls the options of the JSON struct tags (omitempty, related structs and per-field overrides)
+	JSONTags JSONTagsConfig
+
+	// ValidateTags enables go-playground/validator struct tags (ie. `validate:"required,uuid"`)
+	ValidateTags bool
+
+	// ValidateMethods generates a dependency-free Validate() error method per struct, which returns ValidationErrors
+	// (declared next to the structs) listing the path of every invalid field
+	ValidateMethods bool
+
+	// CloneMethods generates a Clone() method per structure returning a deep copy, including optional fields and nested structures
+	CloneMethods bool
 }
 
 func (config MorpheStructuresConfig) Validate() error {
@@ -30,5 +51,12 @@ func (config MorpheStructuresConfig) Validate() error {
 	if !config.FieldCasing.IsValid() {
 		return fmt.Errorf("structures: invalid fieldCasing value %q, must be one of: camel, snake, pascal, or empty", config.FieldCasing)
 	}
+	if !config.FieldOrder.IsValid() {
+		return fmt.Errorf("structures: invalid fieldOrder value %q, must be one of: declaration, or empty", config.FieldOrder)
+	}
+	jsonTagsErr := config.JSONTags.Validate()
+	if jsonTagsErr != nil {
+		return fmt.Errorf("structures: %w", jsonTagsErr)
+	}
 	return nil
 }
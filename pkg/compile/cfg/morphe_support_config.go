@@ -0,0 +1,81 @@
+package cfg
+
+import (
+	"fmt"
+
+	"github.com/kalo-build/go/pkg/godef"
+)
+
+// MorpheSupportConfig controls the support types shared by the generated models, structures and entities.
+// Support types are generated into their own package, which is only required if at least one generated support type is enabled.
+type MorpheSupportConfig struct {
+	Package godef.Package
+
+	// CivilDate maps Date fields to a civil date type serialized as "YYYY-MM-DD" instead of time.Time
+	CivilDate bool
+
+	// DateType is the civil date type used for Date fields, ie. "cloud.google.com/go/civil".Date.
+	// If empty, a Date type with JSON and SQL support is generated in the support package.
+	DateType godef.GoTypeStruct
+
+	// RedactSecrets maps Protected and Sealed fields to wrapper types generated in the support package,
+	// which redact the value when formatted or marshalled to JSON
+	RedactSecrets bool
+}
+
+func (config MorpheSupportConfig) Validate() error {
+	if !config.HasGeneratedTypes() {
+		return nil
+	}
+	if config.Package.Path == "" {
+		return fmt.Errorf("support %w", ErrNoPackagePath)
+	}
+	if config.Package.Name == "" {
+		return fmt.Errorf("support %w", ErrNoPackageName)
+	}
+	return nil
+}
+
+// HasGeneratedTypes returns true if any support type is generated into the support package.
+func (config MorpheSupportConfig) HasGeneratedTypes() bool {
+	return config.IsGeneratedDate() || config.RedactSecrets
+}
+
+// IsGeneratedDate returns true if Date fields use the Date type generated into the support package.
+func (config MorpheSupportConfig) IsGeneratedDate() bool {
+	return config.CivilDate && config.DateType.Name == ""
+}
+
+// GetDateType returns the civil date type used for Date fields, or nil if Date fields map to time.Time.
+func (config MorpheSupportConfig) GetDateType() godef.GoType {
+	if !config.CivilDate {
+		return nil
+	}
+	if config.DateType.Name != "" {
+		return config.DateType
+	}
+	return godef.GoTypeStruct{
+		PackagePath: config.Package.Path,
+		Name:        "Date",
+	}
+}
+
+// GetProtectedType returns the wrapper type used for Protected fields, or nil if Protected fields map to string.
+func (config MorpheSupportConfig) GetProtectedType() godef.GoType {
+	return config.getSecretType("Protected")
+}
+
+// GetSealedType returns the wrapper type used for Sealed fields, or nil if Sealed fields map to string.
+func (config MorpheSupportConfig) GetSealedType() godef.GoType {
+	return config.getSecretType("Sealed")
+}
+
+func (config MorpheSupportConfig) getSecretType(typeName string) godef.GoType {
+	if !config.RedactSecrets {
+		return nil
+	}
+	return godef.GoTypeStruct{
+		PackagePath: config.Package.Path,
+		Name:        typeName,
+	}
+}
@@ -0,0 +1,88 @@
+package cfg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kalo-build/go/pkg/godef"
+)
+
+// MorpheTypeOverridesConfig replaces the Go types generated for Morphe field types or for single model fields.
+// Overrides take precedence over enums, support types and the default type mappings, and also apply to
+// related ID fields, identifier structs and entity fields resolved from the overridden model fields.
+type MorpheTypeOverridesConfig struct {
+	// FieldTypes overrides the Go type of all fields of a Morphe type, ie. "UUID" -> "github.com/google/uuid".UUID
+	FieldTypes map[string]TypeOverride
+
+	// ModelFields overrides the Go type of a single model field, ie. "Invoice.Amount" -> "github.com/shopspring/decimal".Decimal.
+	// Model field overrides take precedence over field type overrides.
+	ModelFields map[string]TypeOverride
+}
+
+// TypeOverride is a Go type used in place of the generated type.
+type TypeOverride struct {
+	// PackagePath is the import path of the type, empty for predeclared types like "int64"
+	PackagePath string
+
+	// Name is the name of the type, ie. "UUID"
+	Name string
+}
+
+func (config MorpheTypeOverridesConfig) Validate() error {
+	for fieldType, override := range config.FieldTypes {
+		if override.Name == "" {
+			return fmt.Errorf("type overrides: %w for field type '%s'", ErrNoTypeOverrideName, fieldType)
+		}
+	}
+	for modelFieldPath, override := range config.ModelFields {
+		pathParts := strings.Split(modelFieldPath, ".")
+		if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] == "" {
+			return fmt.Errorf("type overrides: invalid model field '%s', must be 'Model.Field'", modelFieldPath)
+		}
+		if override.Name == "" {
+			return fmt.Errorf("type overrides: %w for model field '%s'", ErrNoTypeOverrideName, modelFieldPath)
+		}
+	}
+	return nil
+}
+
+// GetModelFieldOverride returns the Go type override of the model field, either for the field itself or for its Morphe field type.
+func (config MorpheTypeOverridesConfig) GetModelFieldOverride(modelName string, fieldName string, fieldType string) (godef.GoType, bool) {
+	override, hasOverride := config.ModelFields[modelName+"."+fieldName]
+	if hasOverride {
+		return override.GetGoType(), true
+	}
+	return config.GetFieldTypeOverride(fieldType)
+}
+
+// GetFieldTypeOverride returns the Go type override of the Morphe field type.
+func (config MorpheTypeOverridesConfig) GetFieldTypeOverride(fieldType string) (godef.GoType, bool) {
+	override, hasOverride := config.FieldTypes[fieldType]
+	if !hasOverride {
+		return nil, false
+	}
+	return override.GetGoType(), true
+}
+
+// IsOverrideType returns true if the Go type is the type of any override.
+func (config MorpheTypeOverridesConfig) IsOverrideType(goType godef.GoType) bool {
+	for _, override := range config.FieldTypes {
+		if override.GetGoType() == goType {
+			return true
+		}
+	}
+	for _, override := range config.ModelFields {
+		if override.GetGoType() == goType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetGoType returns the overriding Go type.
+func (override TypeOverride) GetGoType() godef.GoType {
+	return godef.GoTypeStruct{
+		PackagePath: override.PackagePath,
+		Name:        override.Name,
+	}
+}
@@ -0,0 +1,39 @@
+package cfg
+
+import "fmt"
+
+// ORMTagsConfig enables additional struct tag families for persistence layers using the generated structs directly.
+type ORMTagsConfig struct {
+	// DB adds `db:"..."` tags with the column name, ie. for sqlx
+	DB bool
+
+	// Gorm adds `gorm:"..."` tags with the column name, primary key, auto increment, not null, immutability and relation keys
+	Gorm bool
+
+	// Bun adds `bun:"..."` tags with the column name, primary key, auto increment, not null and relation joins
+	Bun bool
+
+	// ColumnCasing specifies the casing of the column names in all ORM tags. Empty means snake case.
+	// Valid values: "camel", "snake", "pascal", or "" (snake)
+	ColumnCasing Casing
+}
+
+func (config ORMTagsConfig) Validate() error {
+	if !config.ColumnCasing.IsValid() {
+		return fmt.Errorf("invalid columnCasing value %q, must be one of: camel, snake, pascal, or empty", config.ColumnCasing)
+	}
+	return nil
+}
+
+// IsEnabled returns true if any ORM tag family is enabled.
+func (config ORMTagsConfig) IsEnabled() bool {
+	return config.DB || config.Gorm || config.Bun
+}
+
+// GetColumnName returns the column name of a field in the configured casing.
+func (config ORMTagsConfig) GetColumnName(fieldName string) string {
+	if config.ColumnCasing == CasingNone {
+		return CasingSnake.Apply(fieldName)
+	}
+	return config.ColumnCasing.Apply(fieldName)
+}
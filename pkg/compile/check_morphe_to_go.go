@@ -0,0 +1,55 @@
+package compile
+
+import (
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/write"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+)
+
+// CheckMorpheToGo compiles the registry like MorpheToGo, but compares every rendered definition with the existing
+// files instead of writing them. Nothing is written or removed. All configured writers must implement write.GoFileChecker.
+func CheckMorpheToGo(config MorpheCompileConfig) (gofile.CheckResult, error) {
+	allCheckers, checkersErr := getAllGoFileCheckers(config)
+	if checkersErr != nil {
+		return gofile.CheckResult{}, checkersErr
+	}
+
+	for _, checker := range allCheckers {
+		checker.SetCheckOnly(true)
+	}
+	defer func() {
+		for _, checker := range allCheckers {
+			checker.SetCheckOnly(false)
+		}
+	}()
+
+	config.RemoveStaleFiles = false
+	compileErr := MorpheToGo(config)
+	if compileErr != nil {
+		return gofile.CheckResult{}, compileErr
+	}
+
+	allCheckResults := gofile.CheckResult{}
+	for _, checker := range allCheckers {
+		checkResult, checkErr := checker.GetCheckResult()
+		if checkErr != nil {
+			return gofile.CheckResult{}, checkErr
+		}
+		allCheckResults.Merge(checkResult)
+	}
+	return allCheckResults, nil
+}
+
+func getAllGoFileCheckers(config MorpheCompileConfig) ([]write.GoFileChecker, error) {
+	allCheckers := []write.GoFileChecker{}
+	for _, writer := range config.allWriters() {
+		if writer == nil {
+			continue
+		}
+		checker, isChecker := writer.(write.GoFileChecker)
+		if !isChecker {
+			return nil, ErrWriterCheckUnsupported
+		}
+		allCheckers = append(allCheckers, checker)
+	}
+	return allCheckers, nil
+}
@@ -0,0 +1,176 @@
+package compile
+
+import (
+	"fmt"
+
+	"github.com/kalo-build/go-util/strcase"
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+)
+
+// cloneHelpersDefinitionName is the definition (and file) name of the helpers used by the generated Clone methods.
+const cloneHelpersDefinitionName = "Clone"
+
+// cloneHelpersSource declares the helpers of the generated cloneWith methods, see getCloneHelpersFileContents.
+// The clones map tracks the copy of every struct pointer, so that shared and cyclic references (ie. Company <-> Person)
+// are cloned once and stay shared in the copy.
+const cloneHelpersSource = `// cloneValue copies the value of an optional field.
+func cloneValue[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	valueClone := *value
+	return &valueClone
+}
+
+// cloneSlice copies a slice of values, ie. related IDs.
+func cloneSlice[T any](values []T) []T {
+	if values == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(values)), values...)
+}
+
+// clonePointer deep copies a struct pointer once per clone, later references to the same pointer get the same copy.
+func clonePointer[T any](value *T, clones map[any]any, cloneWith func(T, map[any]any) T) *T {
+	if value == nil {
+		return nil
+	}
+	if valueClone, isCloned := clones[value]; isCloned {
+		return valueClone.(*T)
+	}
+	valueClone := new(T)
+	clones[value] = valueClone
+	*valueClone = cloneWith(*value, clones)
+	return valueClone
+}
+
+// cloneStructs deep copies a slice of structs.
+func cloneStructs[T any](values []T, clones map[any]any, cloneWith func(T, map[any]any) T) []T {
+	if values == nil {
+		return nil
+	}
+	valueClones := make([]T, len(values))
+	for valueIdx, value := range values {
+		valueClones[valueIdx] = cloneWith(value, clones)
+	}
+	return valueClones
+}
+`
+
+func getCloneHelpersFileContents(structPackage godef.Package) string {
+	return fmt.Sprintf("%s\n\npackage %s\n\n%s", gofile.GeneratedFileHeader, structPackage.Name, cloneHelpersSource)
+}
+
+// getCloneMethods builds the exported Clone method of a struct and the cloneWith method it delegates to, which deep copies
+// optional fields, slices and the structs of the same package (related structs and nested structures). The marker
+// interfaces of typed polymorphic relations are cloned through their target models, keyed by the interface field name
+// in allPolyTargetNames. Other values are copied as is.
+func getCloneMethods(structPackage godef.Package, receiverName string, structName string, allFields []godef.StructField, allPolyTargetNames map[string][]string) []godef.StructMethod {
+	receiverType := godef.GoTypeStruct{
+		PackagePath: structPackage.Path,
+		Name:        structName,
+	}
+	clonesType := godef.GoTypeMap{
+		KeyType:   godef.GoTypeInterface{Name: "any"},
+		ValueType: godef.GoTypeInterface{Name: "any"},
+	}
+
+	cloneLines := []string{}
+	for _, field := range allFields {
+		if targetNames, isPolyField := allPolyTargetNames[field.Name]; isPolyField {
+			cloneLines = append(cloneLines, getPolyFieldCloneLines(receiverName, field.Name, targetNames)...)
+			continue
+		}
+		cloneExpr := getFieldCloneExpr(structPackage, receiverName, field)
+		if cloneExpr == "" {
+			continue
+		}
+		cloneLines = append(cloneLines, fmt.Sprintf("\tclone.%s = %s", field.Name, cloneExpr))
+	}
+
+	cloneWithLines := []string{
+		fmt.Sprintf("\treturn %s", receiverName),
+	}
+	if len(cloneLines) > 0 {
+		cloneWithLines = []string{
+			fmt.Sprintf("\tclone := %s", receiverName),
+		}
+		cloneWithLines = append(cloneWithLines, cloneLines...)
+		cloneWithLines = append(cloneWithLines, "\treturn clone")
+	}
+
+	return []godef.StructMethod{
+		{
+			ReceiverName: receiverName,
+			ReceiverType: receiverType,
+			Name:         "Clone",
+			ReturnTypes:  []godef.GoType{receiverType},
+			BodyLines: []string{
+				fmt.Sprintf("\treturn %s.cloneWith(map[any]any{})", receiverName),
+			},
+		},
+		{
+			ReceiverName: receiverName,
+			ReceiverType: receiverType,
+			Name:         "cloneWith",
+			Parameters: map[string]godef.GoType{
+				"clones": clonesType,
+			},
+			ReturnTypes: []godef.GoType{receiverType},
+			BodyLines:   cloneWithLines,
+		},
+	}
+}
+
+// getFieldCloneExpr returns the expression copying a field, or an empty string if assigning the field copies it already.
+func getFieldCloneExpr(structPackage godef.Package, receiverName string, field godef.StructField) string {
+	fieldRef := receiverName + "." + field.Name
+	switch fieldType := field.Type.(type) {
+	case godef.GoTypePointer:
+		if structName, isLocalStruct := getLocalStructName(structPackage, fieldType.ValueType); isLocalStruct {
+			return fmt.Sprintf("clonePointer(%s, clones, %s.cloneWith)", fieldRef, structName)
+		}
+		return fmt.Sprintf("cloneValue(%s)", fieldRef)
+	case godef.GoTypeArray:
+		if !fieldType.IsSlice {
+			return ""
+		}
+		if structName, isLocalStruct := getLocalStructName(structPackage, fieldType.ValueType); isLocalStruct {
+			return fmt.Sprintf("cloneStructs(%s, clones, %s.cloneWith)", fieldRef, structName)
+		}
+		return fmt.Sprintf("cloneSlice(%s)", fieldRef)
+	case godef.GoTypeStruct:
+		if _, isLocalStruct := getLocalStructName(structPackage, fieldType); isLocalStruct {
+			return fmt.Sprintf("%s.cloneWith(clones)", fieldRef)
+		}
+	}
+	return ""
+}
+
+// getPolyFieldCloneLines clones the model behind the marker interface of a typed polymorphic relation. Both the target
+// models and pointers to them implement the interface, so the clone keeps the kind of value that was set.
+func getPolyFieldCloneLines(receiverName string, fieldName string, targetNames []string) []string {
+	targetVarName := strcase.ToCamelCase(fieldName)
+	cloneLines := []string{
+		fmt.Sprintf("\tswitch %s := %s.%s.(type) {", targetVarName, receiverName, fieldName),
+	}
+	for _, targetName := range targetNames {
+		cloneLines = append(cloneLines,
+			fmt.Sprintf("\tcase %s:", targetName),
+			fmt.Sprintf("\t\tclone.%s = %s.cloneWith(clones)", fieldName, targetVarName),
+			fmt.Sprintf("\tcase *%s:", targetName),
+			fmt.Sprintf("\t\tclone.%s = clonePointer(%s, clones, %s.cloneWith)", fieldName, targetVarName, targetName),
+		)
+	}
+	return append(cloneLines, "\t}")
+}
+
+// getLocalStructName returns the name of a struct declared in the same package, ie. a related model or a nested structure.
+func getLocalStructName(structPackage godef.Package, goType godef.GoType) (string, bool) {
+	structType, isStructType := goType.(godef.GoTypeStruct)
+	if !isStructType || (structType.PackagePath != "" && structType.PackagePath != structPackage.Path) {
+		return "", false
+	}
+	return structType.Name, true
+}
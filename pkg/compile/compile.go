@@ -3,69 +3,235 @@ package compile
 import (
 	"fmt"
 
+	"github.com/kalo-build/go/pkg/godef"
 	"github.com/kalo-build/morphe-go/pkg/registry"
 )
 
 func MorpheToGo(config MorpheCompileConfig) error {
+	resetAllTrackedFiles(config)
+
 	r, rErr := registry.LoadMorpheRegistry(config.RegistryHooks, config.MorpheLoadRegistryConfig)
 	if rErr != nil {
 		return rErr
 	}
 
+	if usesDeclarationOrder(config.MorpheConfig) && config.DeclarationOrders.IsEmpty() {
+		declarationOrders, declarationOrdersErr := LoadDeclarationOrders(config.MorpheLoadRegistryConfig)
+		if declarationOrdersErr != nil {
+			return declarationOrdersErr
+		}
+		config.DeclarationOrders = declarationOrders
+	}
+
+	descriptions := Descriptions{}
+	if usesDocComments(config.MorpheConfig) {
+		loadedDescriptions, descriptionsErr := LoadDescriptions(config.MorpheLoadRegistryConfig)
+		if descriptionsErr != nil {
+			return descriptionsErr
+		}
+		descriptions = loadedDescriptions
+	}
+	setAllDocComments(config, r, descriptions)
+
+	validateSupportErr := config.MorpheSupportConfig.Validate()
+	if validateSupportErr != nil {
+		return validateSupportErr
+	}
+
 	hasEnums := r.HasEnums()
+	hasModels := r.HasModels()
+	hasStructures := r.HasStructures()
+	hasEntities := r.HasEntities()
+	if hasEntities && !hasModels {
+		return fmt.Errorf("entities compilation requires models to be compiled")
+	}
+
+	// All definitions are compiled before anything is written, so that aggregated errors cover the whole registry
+	allErrs := CompileErrors{}
+
+	var allEnumDefs map[string]*godef.Enum
 	if hasEnums {
-		allEnumDefs, compileAllErr := AllMorpheEnumsToGoEnums(config, r)
-		if compileAllErr != nil {
-			return compileAllErr
+		compiledEnumDefs, compileAllErr := AllMorpheEnumsToGoEnums(config, r)
+		if addErr := addCompileErrors(config, &allErrs, compileAllErr); addErr != nil {
+			setCompileErrorPositions(config, addErr)
+			return addErr
 		}
+		allEnumDefs = compiledEnumDefs
+	}
+
+	var allModelStructDefs map[string][]*godef.Struct
+	var allPolyDefs map[string]string
+	var allConstructorDefs map[string]string
+	if hasModels {
+		compiledModelStructDefs, compileAllErr := AllMorpheModelsToGoStructs(config, r)
+		if addErr := addCompileErrors(config, &allErrs, compileAllErr); addErr != nil {
+			setCompileErrorPositions(config, addErr)
+			return addErr
+		}
+		allModelStructDefs = compiledModelStructDefs
+
+		// The shared definitions are only compiled from valid models, they would repeat the errors of the models otherwise
+		if compileAllErr == nil {
+			compiledPolyDefs, polyErr := AllMorpheModelPolyDefinitions(config, r)
+			if addErr := addCompileErrors(config, &allErrs, polyErr); addErr != nil {
+				setCompileErrorPositions(config, addErr)
+				return addErr
+			}
+			allPolyDefs = compiledPolyDefs
+
+			compiledConstructorDefs, constructorsErr := AllMorpheModelConstructors(config, r)
+			if addErr := addCompileErrors(config, &allErrs, constructorsErr); addErr != nil {
+				setCompileErrorPositions(config, addErr)
+				return addErr
+			}
+			allConstructorDefs = compiledConstructorDefs
+		}
+	}
+
+	var allStructureStructDefs map[string]*godef.Struct
+	if hasStructures {
+		compiledStructureStructDefs, compileAllErr := AllMorpheStructuresToGoStructs(config, r)
+		if addErr := addCompileErrors(config, &allErrs, compileAllErr); addErr != nil {
+			setCompileErrorPositions(config, addErr)
+			return addErr
+		}
+		allStructureStructDefs = compiledStructureStructDefs
+	}
+
+	var allEntityStructDefs map[string][]*godef.Struct
+	var allMapperDefs map[string]string
+	if hasEntities {
+		compiledEntityStructDefs, compileAllErr := AllMorpheEntitiesToGoStructs(config, r)
+		if addErr := addCompileErrors(config, &allErrs, compileAllErr); addErr != nil {
+			setCompileErrorPositions(config, addErr)
+			return addErr
+		}
+		allEntityStructDefs = compiledEntityStructDefs
+
+		if compileAllErr == nil {
+			compiledMapperDefs, mappersErr := AllMorpheEntityModelMappers(config, r)
+			if addErr := addCompileErrors(config, &allErrs, mappersErr); addErr != nil {
+				setCompileErrorPositions(config, addErr)
+				return addErr
+			}
+			allMapperDefs = compiledMapperDefs
+		}
+	}
+
+	if compileErrs := allErrs.Err(); compileErrs != nil {
+		setCompileErrorPositions(config, compileErrs)
+		return compileErrs
+	}
 
+	_, writeSupportErr := WriteAllSupportDefinitions(config)
+	if writeSupportErr != nil {
+		return writeSupportErr
+	}
+
+	if hasEnums {
 		_, writeEnumsErr := WriteAllEnumDefinitions(config, allEnumDefs)
 		if writeEnumsErr != nil {
 			return writeEnumsErr
 		}
 	}
 
-	hasModels := r.HasModels()
 	if hasModels {
-		allModelStructDefs, compileAllErr := AllMorpheModelsToGoStructs(config, r)
-		if compileAllErr != nil {
-			return compileAllErr
-		}
-
 		_, writeModelStructsErr := WriteAllModelStructDefinitions(config, allModelStructDefs)
 		if writeModelStructsErr != nil {
 			return writeModelStructsErr
 		}
-	}
 
-	hasStructures := r.HasStructures()
-	if hasStructures {
-		allStructureStructDefs, compileAllErr := AllMorpheStructuresToGoStructs(config, r)
-		if compileAllErr != nil {
-			return compileAllErr
+		if config.MorpheModelsConfig.ValidateMethods {
+			_, writeValidationErr := WriteValidationErrorsDefinition(config.ModelWriter, config.MorpheModelsConfig.Package)
+			if writeValidationErr != nil {
+				return writeValidationErr
+			}
 		}
 
+		if config.MorpheModelsConfig.CloneMethods {
+			_, writeCloneErr := WriteCloneHelpersDefinition(config.ModelWriter, config.MorpheModelsConfig.Package)
+			if writeCloneErr != nil {
+				return writeCloneErr
+			}
+		}
+
+		if config.MorpheModelsConfig.DiffMethods {
+			_, writeDiffErr := WriteFieldChangeDefinition(config.ModelWriter, config.MorpheModelsConfig.Package)
+			if writeDiffErr != nil {
+				return writeDiffErr
+			}
+		}
+
+		_, writePolyErr := WriteAllModelPolyDefinitions(config, allPolyDefs)
+		if writePolyErr != nil {
+			return writePolyErr
+		}
+
+		_, writeConstructorsErr := WriteAllModelConstructors(config, allConstructorDefs)
+		if writeConstructorsErr != nil {
+			return writeConstructorsErr
+		}
+	}
+
+	if hasStructures {
 		_, writeStructureStructsErr := WriteAllStructureStructDefinitions(config, allStructureStructDefs)
 		if writeStructureStructsErr != nil {
 			return writeStructureStructsErr
 		}
-	}
 
-	hasEntities := r.HasEntities()
-	if hasEntities {
-		if !hasModels {
-			return fmt.Errorf("entities compilation requires models to be compiled")
+		if config.MorpheStructuresConfig.ValidateMethods {
+			_, writeValidationErr := WriteValidationErrorsDefinition(config.StructureWriter, config.MorpheStructuresConfig.Package)
+			if writeValidationErr != nil {
+				return writeValidationErr
+			}
 		}
 
-		allEntityStructDefs, compileAllErr := AllMorpheEntitiesToGoStructs(config, r)
-		if compileAllErr != nil {
-			return compileAllErr
+		if config.MorpheStructuresConfig.CloneMethods {
+			_, writeCloneErr := WriteCloneHelpersDefinition(config.StructureWriter, config.MorpheStructuresConfig.Package)
+			if writeCloneErr != nil {
+				return writeCloneErr
+			}
 		}
+	}
 
+	if hasEntities {
 		_, writeEntityStructsErr := WriteAllEntityStructDefinitions(config, allEntityStructDefs)
 		if writeEntityStructsErr != nil {
 			return writeEntityStructsErr
 		}
+
+		if config.MorpheEntitiesConfig.ValidateMethods {
+			_, writeValidationErr := WriteValidationErrorsDefinition(config.EntityWriter, config.MorpheEntitiesConfig.Package)
+			if writeValidationErr != nil {
+				return writeValidationErr
+			}
+		}
+
+		if config.MorpheEntitiesConfig.CloneMethods {
+			_, writeCloneErr := WriteCloneHelpersDefinition(config.EntityWriter, config.MorpheEntitiesConfig.Package)
+			if writeCloneErr != nil {
+				return writeCloneErr
+			}
+		}
+
+		if config.MorpheEntitiesConfig.DiffMethods {
+			_, writeDiffErr := WriteFieldChangeDefinition(config.EntityWriter, config.MorpheEntitiesConfig.Package)
+			if writeDiffErr != nil {
+				return writeDiffErr
+			}
+		}
+
+		_, writeMappersErr := WriteAllEntityModelMappers(config, allMapperDefs)
+		if writeMappersErr != nil {
+			return writeMappersErr
+		}
+	}
+
+	if config.RemoveStaleFiles {
+		_, removeErr := RemoveAllStaleFiles(config)
+		if removeErr != nil {
+			return removeErr
+		}
 	}
 
 	return nil
@@ -6,25 +6,32 @@ import (
 
 	"github.com/kalo-build/go-util/core"
 	"github.com/kalo-build/go-util/inflect"
-
 	"github.com/kalo-build/go/pkg/godef"
 	"github.com/kalo-build/morphe-go/pkg/registry"
 	"github.com/kalo-build/morphe-go/pkg/yaml"
 	"github.com/kalo-build/morphe-go/pkg/yamlops"
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/hook"
-	"github.com/kalo-build/plugin-morphe-go-struct/pkg/typemap"
 )
 
 func AllMorpheEntitiesToGoStructs(config MorpheCompileConfig, r *registry.Registry) (map[string][]*godef.Struct, error) {
 	allEntityStructDefs := map[string][]*godef.Struct{}
-	for entityName, entity := range r.GetAllEntities() {
+	allErrs := CompileErrors{}
+	allEntities := r.GetAllEntities()
+	for _, entityName := range core.MapKeysSorted(allEntities) {
+		entity := allEntities[entityName]
 		entityStructs, entityStructsErr := MorpheEntityToGoStructs(config.EntityHooks, config.MorpheConfig, r, entity)
 		if entityStructsErr != nil {
-			return nil, entityStructsErr
+			if addErr := addCompileError(config, &allErrs, CompileErrorKindEntity, entityName, entityStructsErr); addErr != nil {
+				return nil, addErr
+			}
+			continue
 		}
 		allEntityStructDefs[entityName] = entityStructs
 	}
+	if compileErrs := allErrs.Err(); compileErrs != nil {
+		return nil, compileErrs
+	}
 	return allEntityStructDefs, nil
 }
 
@@ -72,6 +79,20 @@ func morpheEntityToGoStructs(config cfg.MorpheConfig, r *registry.Registry, enti
 		return nil, identifierStructsErr
 	}
 
+	if config.MorpheEntitiesConfig.ValidateMethods {
+		validateChecks, validateErr := getEntityValidateChecks(config, r, entity)
+		if validateErr != nil {
+			return nil, validateErr
+		}
+		entityStruct.Methods = append(entityStruct.Methods, getValidateMethod(config.MorpheEntitiesConfig.Package, config.MorpheEntitiesConfig.ReceiverName, entity.Name, validateChecks))
+	}
+	if config.MorpheEntitiesConfig.CloneMethods {
+		entityStruct.Methods = append(entityStruct.Methods, getCloneMethods(config.MorpheEntitiesConfig.Package, config.MorpheEntitiesConfig.ReceiverName, entity.Name, entityStruct.Fields, nil)...)
+	}
+	if config.MorpheEntitiesConfig.DiffMethods {
+		entityStruct.Methods = append(entityStruct.Methods, getDiffMethods(config.MorpheEntitiesConfig.Package, config.MorpheTypeOverridesConfig, config.MorpheEntitiesConfig.ReceiverName, entity.Name, entityStruct.Fields)...)
+	}
+
 	allEntityStructs := []*godef.Struct{
 		entityStruct,
 	}
@@ -89,6 +110,7 @@ func getEntityStruct(config cfg.MorpheConfig, r *registry.Registry, entity yaml.
 	if fieldsErr != nil {
 		return nil, fieldsErr
 	}
+	applyJSONTagsConfig(config.MorpheEntitiesConfig.JSONTags, entity.Name, structFields, getRelationFieldNames(getEntityModelRelations(entity)))
 	entityStruct.Fields = structFields
 
 	structImports, importsErr := getImportsForStructFields(config.MorpheEntitiesConfig.Package, structFields)
@@ -100,17 +122,28 @@ func getEntityStruct(config cfg.MorpheConfig, r *registry.Registry, entity yaml.
 	return &entityStruct, nil
 }
 
+// getEntityModelRelations converts the entity relations to model relations, which share the same definition.
+func getEntityModelRelations(entity yaml.Entity) map[string]yaml.ModelRelation {
+	modelRelations := map[string]yaml.ModelRelation{}
+	for relationName, relationDef := range entity.Related {
+		modelRelations[relationName] = yaml.ModelRelation(relationDef)
+	}
+	return modelRelations
+}
+
 func getGoFieldsForMorpheEntity(config cfg.MorpheConfig, r *registry.Registry, entity yaml.Entity) ([]godef.StructField, error) {
 	allFields := []godef.StructField{}
 	fieldCasing := config.MorpheEntitiesConfig.FieldCasing
+	ormTagsConfig := config.MorpheEntitiesConfig.ORMTags
+	primaryFieldNames := entity.Identifiers["primary"].Fields
 
-	allFieldNames := core.MapKeysSorted(entity.Fields)
+	allFieldNames := getOrderedFieldNames(config.MorpheEntitiesConfig.FieldOrder, config.DeclarationOrders.Entities[entity.Name], entity.Fields, wrapEntityIdentifiers(entity.Identifiers))
 	// Handle direct fields
 	for _, fieldName := range allFieldNames {
 		entityField := entity.Fields[fieldName]
 		fieldType, fieldErr := getModelFieldType(config, r, entityField.Type, fieldCasing)
 		if fieldErr != nil {
-			return nil, fieldErr
+			return nil, ErrMorpheField(fieldName, fieldErr)
 		}
 
 		// Entity fields are required by default; wrap in pointer for "optional" attribute
@@ -118,16 +151,33 @@ func getGoFieldsForMorpheEntity(config cfg.MorpheConfig, r *registry.Registry, e
 			fieldType = godef.GoTypePointer{ValueType: fieldType}
 		}
 
+		tags := buildFieldTags(fieldName, entityField.Attributes, fieldCasing)
+		if ormTagsConfig.IsEnabled() || config.MorpheEntitiesConfig.ValidateTags {
+			model, modelFieldName, modelField, modelFieldErr := getModelFieldByPath(r, entityField.Type)
+			if modelFieldErr != nil {
+				return nil, ErrMorpheField(fieldName, modelFieldErr)
+			}
+			ormField := getORMDirectField(fieldName, modelField.Type, entityField.Attributes, primaryFieldNames)
+			tags = append(tags, buildORMFieldTags(ormTagsConfig, fieldName, ormField)...)
+
+			if config.MorpheEntitiesConfig.ValidateTags {
+				isOptional := hasAttribute(entityField.Attributes, "optional")
+				_, hasOverride := config.MorpheTypeOverridesConfig.GetModelFieldOverride(model.Name, modelFieldName, string(modelField.Type))
+				validateRules := getValidateFieldRules(string(modelField.Type), isOptional, hasOverride, r.GetAllEnums())
+				tags = append(tags, buildValidateFieldTags(validateRules, isOptional)...)
+			}
+		}
+
 		field := godef.StructField{
 			Name: fieldName,
 			Type: fieldType,
-			Tags: buildFieldTags(fieldName, entityField.Attributes, fieldCasing),
+			Tags: tags,
 		}
 		allFields = append(allFields, field)
 	}
 
 	// Handle related entities
-	relatedFields, relatedErr := getRelatedGoFieldsForMorpheEntity(config, r, entity.Related, fieldCasing)
+	relatedFields, relatedErr := getRelatedGoFieldsForMorpheEntity(config, r, entity, fieldCasing)
 	if relatedErr != nil {
 		return nil, relatedErr
 	}
@@ -136,22 +186,24 @@ func getGoFieldsForMorpheEntity(config cfg.MorpheConfig, r *registry.Registry, e
 	return allFields, nil
 }
 
-func getRelatedGoFieldsForMorpheEntity(config cfg.MorpheConfig, r *registry.Registry, entityRelations map[string]yaml.EntityRelation, fieldCasing cfg.Casing) ([]godef.StructField, error) {
+func getRelatedGoFieldsForMorpheEntity(config cfg.MorpheConfig, r *registry.Registry, entity yaml.Entity, fieldCasing cfg.Casing) ([]godef.StructField, error) {
 	allFields := []godef.StructField{}
+	ormTagsConfig := config.MorpheEntitiesConfig.ORMTags
 
-	allRelatedEntityNames := core.MapKeysSorted(entityRelations)
+	allRelatedEntityNames := getOrderedRelationNames(config.MorpheEntitiesConfig.FieldOrder, config.DeclarationOrders.Entities[entity.Name], entity.Related)
 	for _, relationshipName := range allRelatedEntityNames {
-		relation := entityRelations[relationshipName]
+		relation := entity.Related[relationshipName]
 
 		if yamlops.IsRelationPoly(relation.Type) && yamlops.IsRelationFor(relation.Type) {
 			if len(relation.For) == 0 {
-				return nil, fmt.Errorf("polymorphic relation '%s' must have at least one entity in 'for' property", relationshipName)
+				return nil, ErrMorpheField(relationshipName, fmt.Errorf("polymorphic relation '%s' must have at least one entity in 'for' property", relationshipName))
 			}
 			typeFieldName := relationshipName + "Type"
 			typeFieldType := godef.GoType(godef.GoTypeString)
 			idFieldName := relationshipName + "ID"
 			idFieldType := godef.GoType(godef.GoTypeString)
-			if hasAttribute(relation.Attributes, "optional") {
+			isOptional := hasAttribute(relation.Attributes, "optional")
+			if isOptional {
 				typeFieldType = godef.GoTypePointer{ValueType: godef.GoTypeString}
 				idFieldType = godef.GoTypePointer{ValueType: godef.GoTypeString}
 			}
@@ -160,6 +212,10 @@ func getRelatedGoFieldsForMorpheEntity(config cfg.MorpheConfig, r *registry.Regi
 				Type: typeFieldType,
 				Tags: buildFieldTags(typeFieldName, nil, fieldCasing),
 			}
+			typeField.Tags = append(typeField.Tags, buildORMFieldTags(ormTagsConfig, typeFieldName, getORMPolyField(yaml.ModelRelation(relation)))...)
+			if config.MorpheEntitiesConfig.ValidateTags {
+				typeField.Tags = append(typeField.Tags, buildValidateFieldTags(getValidatePolyTypeFieldRules(relation.For, isOptional), isOptional)...)
+			}
 			allFields = append(allFields, typeField)
 
 			idField := godef.StructField{
@@ -167,6 +223,10 @@ func getRelatedGoFieldsForMorpheEntity(config cfg.MorpheConfig, r *registry.Regi
 				Type: idFieldType,
 				Tags: buildFieldTags(idFieldName, nil, fieldCasing),
 			}
+			idField.Tags = append(idField.Tags, buildORMFieldTags(ormTagsConfig, idFieldName, getORMPolyField(yaml.ModelRelation(relation)))...)
+			if config.MorpheEntitiesConfig.ValidateTags {
+				idField.Tags = append(idField.Tags, buildValidateFieldTags(getValidatePolyIDFieldRules(isOptional), isOptional)...)
+			}
 			allFields = append(allFields, idField)
 			continue
 		}
@@ -186,26 +246,76 @@ func getRelatedGoFieldsForMorpheEntity(config cfg.MorpheConfig, r *registry.Regi
 		// Get target entity
 		targetEntity, entityErr := r.GetEntity(targetEntityName)
 		if entityErr != nil {
-			return nil, fmt.Errorf("failed to get target entity for relation %s: %w", relationshipName, entityErr)
+			return nil, ErrMorpheField(relationshipName, fmt.Errorf("failed to get target entity for relation %s: %w", relationshipName, entityErr))
 		}
 
 		idField, idErr := getRelatedGoFieldForEntityPrimaryID(config, r, relationshipName, targetEntity, relation, fieldCasing)
 		if idErr != nil {
-			return nil, idErr
+			return nil, ErrMorpheField(relationshipName, idErr)
 		}
-		allFields = append(allFields, idField)
 
 		// Add entity reference field
 		entityField, entityErr := getRelatedGoFieldForEntity(relationshipName, targetEntity, relation, fieldCasing)
 		if entityErr != nil {
-			return nil, entityErr
+			return nil, ErrMorpheField(relationshipName, entityErr)
 		}
-		allFields = append(allFields, entityField)
+		addEntityRelatedORMTags(ormTagsConfig, entity, relationshipName, relation, targetEntity, &idField, &entityField)
+		validateErr := addEntityRelatedValidateTags(config, r, relation, targetEntity, &idField, &entityField)
+		if validateErr != nil {
+			return nil, ErrMorpheField(relationshipName, validateErr)
+		}
+		allFields = append(allFields, idField, entityField)
 	}
 
 	return allFields, nil
 }
 
+func addEntityRelatedORMTags(config cfg.ORMTagsConfig, entity yaml.Entity, relationshipName string, relation yaml.EntityRelation, targetEntity yaml.Entity, goIDField *godef.StructField, goEntityField *godef.StructField) {
+	if !config.IsEnabled() {
+		return
+	}
+	relationDef := yaml.ModelRelation(relation)
+	primaryIDFieldName := getEntityPrimaryIDFieldName(entity)
+	targetPrimaryIDFieldName := getEntityPrimaryIDFieldName(targetEntity)
+	targetRelations := map[string]yaml.ModelRelation{}
+	for targetRelationName, targetRelation := range targetEntity.Related {
+		targetRelations[targetRelationName] = yaml.ModelRelation(targetRelation)
+	}
+
+	goIDField.Tags = append(goIDField.Tags, buildORMFieldTags(config, goIDField.Name, getORMRelatedIDField(relationDef))...)
+
+	relatedORMField := getORMRelatedField(entity.Name, primaryIDFieldName, relationshipName, relationDef, goIDField.Name, targetEntity.Name, targetPrimaryIDFieldName, targetRelations)
+	goEntityField.Tags = append(goEntityField.Tags, buildORMFieldTags(config, goEntityField.Name, relatedORMField)...)
+}
+
+// addEntityRelatedValidateTags appends the validate tags to the related ID field and the field holding the related entity(ies) of a relation.
+func addEntityRelatedValidateTags(config cfg.MorpheConfig, r *registry.Registry, relation yaml.EntityRelation, targetEntity yaml.Entity, goIDField *godef.StructField, goEntityField *godef.StructField) error {
+	if !config.MorpheEntitiesConfig.ValidateTags {
+		return nil
+	}
+	targetPrimaryIDField := targetEntity.Fields[getEntityPrimaryIDFieldName(targetEntity)]
+	model, modelFieldName, modelField, modelFieldErr := getModelFieldByPath(r, targetPrimaryIDField.Type)
+	if modelFieldErr != nil {
+		return modelFieldErr
+	}
+	_, hasOverride := config.MorpheTypeOverridesConfig.GetModelFieldOverride(model.Name, modelFieldName, string(modelField.Type))
+	isOptional := hasAttribute(relation.Attributes, "optional")
+
+	idRules := getValidateRelatedIDFieldRules(relation.Type, string(modelField.Type), hasOverride, isOptional)
+	goIDField.Tags = append(goIDField.Tags, buildValidateFieldTags(idRules, isOptional)...)
+	goEntityField.Tags = append(goEntityField.Tags, buildValidateFieldTags(getValidateRelatedFieldRules(relation.Type), false)...)
+	return nil
+}
+
+// getEntityPrimaryIDFieldName returns the field of a single field primary identifier, or an empty string otherwise.
+func getEntityPrimaryIDFieldName(entity yaml.Entity) string {
+	primaryID := entity.Identifiers["primary"]
+	if len(primaryID.Fields) != 1 {
+		return ""
+	}
+	return primaryID.Fields[0]
+}
+
 func getRelatedGoFieldForEntityPrimaryID(config cfg.MorpheConfig, r *registry.Registry, relationName string, targetEntity yaml.Entity, relation yaml.EntityRelation, fieldCasing cfg.Casing) (godef.StructField, error) {
 	primaryID, hasPrimary := targetEntity.Identifiers["primary"]
 	if !hasPrimary {
@@ -281,16 +391,43 @@ func getRelatedGoFieldForEntity(relationName string, targetEntity yaml.Entity, r
 }
 
 func getModelFieldType(config cfg.MorpheConfig, r *registry.Registry, fieldType yaml.ModelFieldPath, fieldCasing cfg.Casing) (godef.GoType, error) {
+	currentModel, terminalFieldName, terminalField, fieldErr := getModelFieldByPath(r, fieldType)
+	if fieldErr != nil {
+		return nil, fieldErr
+	}
+
+	_, hasOverride := config.MorpheTypeOverridesConfig.GetModelFieldOverride(currentModel.Name, terminalFieldName, string(terminalField.Type))
+	goEnumField := getEnumFieldAsStructFieldType(
+		config.MorpheEnumsConfig.Package,
+		r.GetAllEnums(),
+		terminalFieldName,
+		string(terminalField.Type),
+		fieldCasing,
+	)
+	if !hasOverride && goEnumField.Name != "" && goEnumField.Type != nil {
+		return goEnumField.Type, nil
+	}
+
+	goFieldType, supported := getModelFieldGoType(config, currentModel.Name, terminalFieldName, terminalField.Type)
+	if !supported {
+		return nil, fmt.Errorf("morphe entity field %s has unsupported type: %s", fieldType, terminalField.Type)
+	}
+
+	return goFieldType, nil
+}
+
+// getModelFieldByPath resolves an entity field path (ie. "Person.Company.Name") to the terminal model and its field.
+func getModelFieldByPath(r *registry.Registry, fieldType yaml.ModelFieldPath) (yaml.Model, string, yaml.ModelField, error) {
 	fieldPath := strings.Split(string(fieldType), ".")
 	if len(fieldPath) < 2 {
-		return nil, fmt.Errorf("invalid field type path: %s", fieldType)
+		return yaml.Model{}, "", yaml.ModelField{}, fmt.Errorf("invalid field type path: %s", fieldType)
 	}
 
 	// Get root model
 	rootModelName := fieldPath[0]
 	currentModel, modelErr := r.GetModel(rootModelName)
 	if modelErr != nil {
-		return nil, fmt.Errorf("morphe entity field %s references unknown root model: %s", fieldType, rootModelName)
+		return yaml.Model{}, "", yaml.ModelField{}, fmt.Errorf("morphe entity field %s references unknown root model: %s", fieldType, rootModelName)
 	}
 
 	// Traverse through related models
@@ -298,7 +435,7 @@ func getModelFieldType(config cfg.MorpheConfig, r *registry.Registry, fieldType
 		relationshipName := fieldPath[fieldIdx]
 		relationDef, exists := currentModel.Related[relationshipName]
 		if !exists {
-			return nil, fmt.Errorf("morphe entity field %s references unknown related model: %s", fieldType, relationshipName)
+			return yaml.Model{}, "", yaml.ModelField{}, fmt.Errorf("morphe entity field %s references unknown related model: %s", fieldType, relationshipName)
 		}
 
 		// Resolve actual target model name (handles aliasing)
@@ -306,7 +443,7 @@ func getModelFieldType(config cfg.MorpheConfig, r *registry.Registry, fieldType
 
 		relatedModel, relatedErr := r.GetModel(targetModelName)
 		if relatedErr != nil {
-			return nil, fmt.Errorf("morphe entity field %s references invalid related model: %s", fieldType, relationshipName)
+			return yaml.Model{}, "", yaml.ModelField{}, fmt.Errorf("morphe entity field %s references invalid related model: %s", fieldType, relationshipName)
 		}
 		currentModel = relatedModel
 	}
@@ -315,26 +452,10 @@ func getModelFieldType(config cfg.MorpheConfig, r *registry.Registry, fieldType
 	terminalFieldName := fieldPath[len(fieldPath)-1]
 	terminalField, exists := currentModel.Fields[terminalFieldName]
 	if !exists {
-		return nil, fmt.Errorf("morphe entity field %s references unknown model field: %s", fieldType, terminalFieldName)
+		return yaml.Model{}, "", yaml.ModelField{}, fmt.Errorf("morphe entity field %s references unknown model field: %s", fieldType, terminalFieldName)
 	}
 
-	goEnumField := getEnumFieldAsStructFieldType(
-		config.MorpheEnumsConfig.Package,
-		r.GetAllEnums(),
-		terminalFieldName,
-		string(terminalField.Type),
-		fieldCasing,
-	)
-	if goEnumField.Name != "" && goEnumField.Type != nil {
-		return goEnumField.Type, nil
-	}
-
-	goFieldType, supported := typemap.MorpheModelFieldToGoField[terminalField.Type]
-	if !supported {
-		return nil, fmt.Errorf("morphe entity field %s has unsupported type: %s", fieldType, terminalField.Type)
-	}
-
-	return goFieldType, nil
+	return currentModel, terminalFieldName, terminalField, nil
 }
 
 func triggerCompileMorpheEntityStart(hooks hook.CompileMorpheEntity, config cfg.MorpheConfig, entity yaml.Entity) (cfg.MorpheConfig, yaml.Entity, error) {
@@ -3207,3 +3207,898 @@ func (suite *CompileEntitiesTestSuite) TestMorpheEntityToGoStructs_Related_HasOn
 		},
 	}, structFields0[3].Type)
 }
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToGoStructs_CivilDate() {
+	config := cfg.MorpheConfig{
+		MorpheModelsConfig: cfg.MorpheModelsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/models",
+				Name: "models",
+			},
+			ReceiverName: "m",
+		},
+		MorpheStructuresConfig: cfg.MorpheStructuresConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/structures",
+				Name: "structures",
+			},
+			ReceiverName: "s",
+		},
+		MorpheEnumsConfig: cfg.MorpheEnumsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/enums",
+				Name: "enums",
+			},
+		},
+		MorpheEntitiesConfig: cfg.MorpheEntitiesConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/entities",
+				Name: "entities",
+			},
+			ReceiverName: "e",
+		},
+		MorpheSupportConfig: cfg.MorpheSupportConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/support",
+				Name: "support",
+			},
+			CivilDate: true,
+		},
+	}
+
+	entity0 := yaml.Entity{
+		Name: "User",
+		Fields: map[string]yaml.EntityField{
+			"UUID": {
+				Type: "User.UUID",
+			},
+			"Birthday": {
+				Type: "User.Child.Birthday",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"UUID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+
+	r := registry.NewRegistry()
+	r.SetModel("User", yaml.Model{
+		Name: "User",
+		Fields: map[string]yaml.ModelField{
+			"UUID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Child": {
+				Type: "HasOne",
+			},
+		},
+	})
+	r.SetModel("Child", yaml.Model{
+		Name: "Child",
+		Fields: map[string]yaml.ModelField{
+			"UUID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+			"Birthday": {
+				Type: yaml.ModelFieldTypeDate,
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"User": {
+				Type: "ForOne",
+			},
+		},
+	})
+
+	allGoStructs, allStructsErr := compile.MorpheEntityToGoStructs(hook.CompileMorpheEntity{}, config, r, entity0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	goStruct0 := allGoStructs[0]
+	suite.Equal([]string{"github.com/kalo-build/project/domain/support"}, goStruct0.Imports)
+
+	structFields0 := goStruct0.Fields
+	suite.Len(structFields0, 2)
+	suite.Equal("Birthday", structFields0[0].Name)
+	suite.Equal(godef.GoTypeStruct{
+		PackagePath: "github.com/kalo-build/project/domain/support",
+		Name:        "Date",
+	}, structFields0[0].Type)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToGoStructs_TypeOverrides() {
+	config := cfg.MorpheConfig{
+		MorpheModelsConfig: cfg.MorpheModelsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/models",
+				Name: "models",
+			},
+			ReceiverName: "m",
+		},
+		MorpheStructuresConfig: cfg.MorpheStructuresConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/structures",
+				Name: "structures",
+			},
+			ReceiverName: "s",
+		},
+		MorpheEnumsConfig: cfg.MorpheEnumsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/enums",
+				Name: "enums",
+			},
+		},
+		MorpheEntitiesConfig: cfg.MorpheEntitiesConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/entities",
+				Name: "entities",
+			},
+			ReceiverName: "e",
+		},
+		MorpheTypeOverridesConfig: cfg.MorpheTypeOverridesConfig{
+			FieldTypes: map[string]cfg.TypeOverride{
+				"UUID": {
+					PackagePath: "github.com/google/uuid",
+					Name:        "UUID",
+				},
+			},
+			ModelFields: map[string]cfg.TypeOverride{
+				"Child.Amount": {
+					PackagePath: "github.com/shopspring/decimal",
+					Name:        "Decimal",
+				},
+			},
+		},
+	}
+
+	entity0 := yaml.Entity{
+		Name: "User",
+		Fields: map[string]yaml.EntityField{
+			"UUID": {
+				Type: "User.UUID",
+			},
+			"Amount": {
+				Type: "User.Child.Amount",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"UUID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+
+	r := registry.NewRegistry()
+	r.SetModel("User", yaml.Model{
+		Name: "User",
+		Fields: map[string]yaml.ModelField{
+			"UUID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Child": {
+				Type: "HasOne",
+			},
+		},
+	})
+	r.SetModel("Child", yaml.Model{
+		Name: "Child",
+		Fields: map[string]yaml.ModelField{
+			"UUID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+			"Amount": {
+				Type: yaml.ModelFieldTypeFloat,
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"User": {
+				Type: "ForOne",
+			},
+		},
+	})
+
+	allGoStructs, allStructsErr := compile.MorpheEntityToGoStructs(hook.CompileMorpheEntity{}, config, r, entity0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	uuidType := godef.GoTypeStruct{
+		PackagePath: "github.com/google/uuid",
+		Name:        "UUID",
+	}
+
+	goStruct0 := allGoStructs[0]
+	suite.Equal([]string{"github.com/google/uuid", "github.com/shopspring/decimal"}, goStruct0.Imports)
+
+	structFields0 := goStruct0.Fields
+	suite.Len(structFields0, 2)
+	suite.Equal("Amount", structFields0[0].Name)
+	suite.Equal(godef.GoTypeStruct{
+		PackagePath: "github.com/shopspring/decimal",
+		Name:        "Decimal",
+	}, structFields0[0].Type)
+	suite.Equal("UUID", structFields0[1].Name)
+	suite.Equal(uuidType, structFields0[1].Type)
+
+	goStruct1 := allGoStructs[1]
+	suite.Equal("UserIDPrimary", goStruct1.Name)
+	suite.Len(goStruct1.Fields, 1)
+	suite.Equal(uuidType, goStruct1.Fields[0].Type)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToGoStructs_ORMTags() {
+	config := cfg.MorpheConfig{
+		MorpheModelsConfig: cfg.MorpheModelsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/models",
+				Name: "models",
+			},
+			ReceiverName: "m",
+		},
+		MorpheStructuresConfig: cfg.MorpheStructuresConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/structures",
+				Name: "structures",
+			},
+			ReceiverName: "s",
+		},
+		MorpheEnumsConfig: cfg.MorpheEnumsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/enums",
+				Name: "enums",
+			},
+		},
+		MorpheEntitiesConfig: cfg.MorpheEntitiesConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/entities",
+				Name: "entities",
+			},
+			ReceiverName: "e",
+			ORMTags: cfg.ORMTagsConfig{
+				DB:   true,
+				Gorm: true,
+				Bun:  true,
+			},
+		},
+	}
+
+	entity0 := yaml.Entity{
+		Name: "User",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "User.ID",
+			},
+			"Nickname": {
+				Type: "User.Nickname",
+				Attributes: []string{
+					"optional",
+				},
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{
+			"Team": {
+				Type: "ForOne",
+			},
+			"Post": {
+				Type: "HasMany",
+			},
+		},
+	}
+	entity1 := yaml.Entity{
+		Name: "Team",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "Team.ID",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+	}
+	entity2 := yaml.Entity{
+		Name: "Post",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "Post.ID",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{
+			"Writer": {
+				Type:    "ForOne",
+				Aliased: "User",
+			},
+		},
+	}
+
+	r := registry.NewRegistry()
+	r.SetModel("User", yaml.Model{
+		Name: "User",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Nickname": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+	})
+	r.SetModel("Team", yaml.Model{
+		Name: "Team",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+		},
+	})
+	r.SetModel("Post", yaml.Model{
+		Name: "Post",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+	})
+	r.SetEntity("User", entity0)
+	r.SetEntity("Team", entity1)
+	r.SetEntity("Post", entity2)
+
+	allGoStructs, allStructsErr := compile.MorpheEntityToGoStructs(hook.CompileMorpheEntity{}, config, r, entity0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	structFields0 := allGoStructs[0].Fields
+	suite.Len(structFields0, 6)
+
+	suite.Equal("ID", structFields0[0].Name)
+	suite.Equal([]string{
+		`db:"id"`,
+		`gorm:"column:id;primaryKey;autoIncrement"`,
+		`bun:"id,pk,autoincrement"`,
+	}, structFields0[0].Tags)
+
+	suite.Equal("Nickname", structFields0[1].Name)
+	suite.Equal([]string{
+		`morphe:"optional"`,
+		`db:"nickname"`,
+		`gorm:"column:nickname"`,
+		`bun:"nickname"`,
+	}, structFields0[1].Tags)
+
+	suite.Equal("PostIDs", structFields0[2].Name)
+	suite.Equal([]string{`db:"-"`, `gorm:"-"`, `bun:"-"`}, structFields0[2].Tags)
+
+	suite.Equal("Posts", structFields0[3].Name)
+	suite.Equal([]string{
+		`db:"-"`,
+		`gorm:"foreignKey:WriterID;references:ID"`,
+		`bun:"rel:has-many,join:id=writer_id"`,
+	}, structFields0[3].Tags)
+
+	suite.Equal("TeamID", structFields0[4].Name)
+	suite.Equal([]string{
+		`db:"team_id"`,
+		`gorm:"column:team_id;not null"`,
+		`bun:"team_id,notnull"`,
+	}, structFields0[4].Tags)
+
+	suite.Equal("Team", structFields0[5].Name)
+	suite.Equal([]string{
+		`db:"-"`,
+		`gorm:"foreignKey:TeamID;references:ID"`,
+		`bun:"rel:belongs-to,join:team_id=id"`,
+	}, structFields0[5].Tags)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToGoStructs_JSONTags() {
+	config := cfg.MorpheConfig{
+		MorpheModelsConfig: cfg.MorpheModelsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/models",
+				Name: "models",
+			},
+			ReceiverName: "m",
+		},
+		MorpheStructuresConfig: cfg.MorpheStructuresConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/structures",
+				Name: "structures",
+			},
+			ReceiverName: "s",
+		},
+		MorpheEnumsConfig: cfg.MorpheEnumsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/enums",
+				Name: "enums",
+			},
+		},
+		MorpheEntitiesConfig: cfg.MorpheEntitiesConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/entities",
+				Name: "entities",
+			},
+			ReceiverName: "e",
+			FieldCasing:  cfg.CasingSnake,
+			JSONTags: cfg.JSONTagsConfig{
+				OmitOptional: cfg.JSONOmitEmpty,
+				Relations:    cfg.JSONRelationsOmitEmpty,
+			},
+		},
+	}
+
+	entity0 := yaml.Entity{
+		Name: "User",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "User.ID",
+			},
+			"Nickname": {
+				Type: "User.Nickname",
+				Attributes: []string{
+					"optional",
+				},
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{
+			"Team": {
+				Type: "ForOne",
+			},
+		},
+	}
+	entity1 := yaml.Entity{
+		Name: "Team",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "Team.ID",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+	}
+
+	r := registry.NewRegistry()
+	r.SetModel("User", yaml.Model{
+		Name: "User",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Nickname": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+	})
+	r.SetModel("Team", yaml.Model{
+		Name: "Team",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+	})
+	r.SetEntity("User", entity0)
+	r.SetEntity("Team", entity1)
+
+	allGoStructs, allStructsErr := compile.MorpheEntityToGoStructs(hook.CompileMorpheEntity{}, config, r, entity0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	structFields0 := allGoStructs[0].Fields
+	suite.Len(structFields0, 4)
+
+	suite.Equal("ID", structFields0[0].Name)
+	suite.Equal([]string{`json:"id"`}, structFields0[0].Tags)
+
+	suite.Equal("Nickname", structFields0[1].Name)
+	suite.Equal([]string{`morphe:"optional"`, `json:"nickname,omitempty"`}, structFields0[1].Tags)
+
+	suite.Equal("TeamID", structFields0[2].Name)
+	suite.Equal([]string{`json:"team_id"`}, structFields0[2].Tags)
+
+	suite.Equal("Team", structFields0[3].Name)
+	suite.Equal([]string{`json:"team,omitempty"`}, structFields0[3].Tags)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToGoStructs_ValidateTags() {
+	config := cfg.MorpheConfig{
+		MorpheModelsConfig: cfg.MorpheModelsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/models",
+				Name: "models",
+			},
+			ReceiverName: "m",
+		},
+		MorpheStructuresConfig: cfg.MorpheStructuresConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/structures",
+				Name: "structures",
+			},
+			ReceiverName: "s",
+		},
+		MorpheEnumsConfig: cfg.MorpheEnumsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/enums",
+				Name: "enums",
+			},
+		},
+		MorpheEntitiesConfig: cfg.MorpheEntitiesConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/entities",
+				Name: "entities",
+			},
+			ReceiverName: "e",
+			ValidateTags: true,
+		},
+	}
+
+	entity0 := yaml.Entity{
+		Name: "User",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "User.ID",
+			},
+			"Nickname": {
+				Type: "User.Nickname",
+				Attributes: []string{
+					"optional",
+				},
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{
+			"Team": {
+				Type: "ForOne",
+			},
+			"Post": {
+				Type: "HasMany",
+			},
+		},
+	}
+	entity1 := yaml.Entity{
+		Name: "Team",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "Team.ID",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+	}
+	entity2 := yaml.Entity{
+		Name: "Post",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "Post.ID",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{
+			"Writer": {
+				Type:    "ForOne",
+				Aliased: "User",
+			},
+		},
+	}
+
+	r := registry.NewRegistry()
+	r.SetModel("User", yaml.Model{
+		Name: "User",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Nickname": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+	})
+	r.SetModel("Team", yaml.Model{
+		Name: "Team",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+		},
+	})
+	r.SetModel("Post", yaml.Model{
+		Name: "Post",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+	})
+	r.SetEntity("User", entity0)
+	r.SetEntity("Team", entity1)
+	r.SetEntity("Post", entity2)
+
+	allGoStructs, allStructsErr := compile.MorpheEntityToGoStructs(hook.CompileMorpheEntity{}, config, r, entity0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	structFields0 := allGoStructs[0].Fields
+	suite.Len(structFields0, 6)
+
+	suite.Equal("ID", structFields0[0].Name)
+	suite.Nil(structFields0[0].Tags)
+
+	suite.Equal("Nickname", structFields0[1].Name)
+	suite.Equal([]string{`morphe:"optional"`}, structFields0[1].Tags)
+
+	suite.Equal("PostIDs", structFields0[2].Name)
+	suite.Nil(structFields0[2].Tags)
+
+	suite.Equal("Posts", structFields0[3].Name)
+	suite.Equal([]string{`validate:"dive"`}, structFields0[3].Tags)
+
+	suite.Equal("TeamID", structFields0[4].Name)
+	suite.Equal([]string{`validate:"required,uuid"`}, structFields0[4].Tags)
+
+	suite.Equal("Team", structFields0[5].Name)
+	suite.Nil(structFields0[5].Tags)
+}
+
+func (suite *CompileEntitiesTestSuite) TestAllMorpheEntityModelMappers() {
+	config := cfg.MorpheConfig{
+		MorpheModelsConfig: cfg.MorpheModelsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/models",
+				Name: "models",
+			},
+			ReceiverName: "m",
+			TypedIDs:     true,
+		},
+		MorpheEnumsConfig: cfg.MorpheEnumsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/enums",
+				Name: "enums",
+			},
+		},
+		MorpheEntitiesConfig: cfg.MorpheEntitiesConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/entities",
+				Name: "entities",
+			},
+			ReceiverName: "e",
+			ModelMappers: true,
+		},
+	}
+
+	r := registry.NewRegistry()
+	r.SetModel("User", yaml.Model{
+		Name: "User",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Nickname": {
+				Type: yaml.ModelFieldTypeString,
+			},
+			"Bio": {
+				Type:       yaml.ModelFieldTypeString,
+				Attributes: []string{"optional"},
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Team": {
+				Type: "ForOne",
+			},
+		},
+	})
+	r.SetModel("Team", yaml.Model{
+		Name: "Team",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Name": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"User": {
+				Type: "HasMany",
+			},
+		},
+	})
+	r.SetEntity("User", yaml.Entity{
+		Name: "User",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "User.ID",
+			},
+			"Nickname": {
+				Type:       "User.Nickname",
+				Attributes: []string{"optional"},
+			},
+			"Bio": {
+				Type: "User.Bio",
+			},
+			"TeamName": {
+				Type: "User.Team.Name",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{
+			"Team": {
+				Type: "ForOne",
+			},
+		},
+	})
+	r.SetEntity("Team", yaml.Entity{
+		Name: "Team",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "Team.ID",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{
+			"User": {
+				Type: "HasMany",
+			},
+		},
+	})
+
+	allMapperDefs, mappersErr := compile.AllMorpheEntityModelMappers(compile.MorpheCompileConfig{MorpheConfig: config}, r)
+
+	suite.Nil(mappersErr)
+	suite.Len(allMapperDefs, 2)
+
+	userMapper := allMapperDefs["UserFromModel"]
+	suite.Contains(userMapper, "import (\n\t\"errors\"\n\n\t\"github.com/kalo-build/project/domain/models\"\n)\n")
+	suite.Contains(userMapper, "func UserFromModel(m models.User) (User, error) {\n\tentity := User{}\n")
+	suite.Contains(userMapper, "\tif m.Bio == nil {\n\t\treturn User{}, errors.New(\"mapping User.Bio: model field User.Bio is not set\")\n\t}\n\tentity.Bio = *m.Bio\n")
+	suite.Contains(userMapper, "\tentity.ID = uint(m.ID)\n")
+	suite.Contains(userMapper, "\tnicknameValue := m.Nickname\n\tentity.Nickname = &nicknameValue\n")
+	suite.Contains(userMapper, "\tif m.Team == nil {\n\t\treturn User{}, errors.New(\"mapping User.TeamName: model relation User.Team is not loaded\")\n\t}\n\tentity.TeamName = m.Team.Name\n")
+	suite.Contains(userMapper, "\tentity.TeamID = uint(m.TeamID)\n")
+
+	teamMapper := allMapperDefs["TeamFromModel"]
+	suite.NotContains(teamMapper, "\"errors\"")
+	suite.Contains(teamMapper, "\t\tentity.UserIDs = make([]uint, len(m.UserIDs))\n")
+	suite.Contains(teamMapper, "\t\t\tentity.UserIDs[idx] = uint(id)\n")
+}
+
+func (suite *CompileEntitiesTestSuite) TestAllMorpheEntityModelMappers_ManyRelationPath() {
+	config := cfg.MorpheConfig{
+		MorpheModelsConfig: cfg.MorpheModelsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/models",
+				Name: "models",
+			},
+			ReceiverName: "m",
+		},
+		MorpheEnumsConfig: cfg.MorpheEnumsConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/enums",
+				Name: "enums",
+			},
+		},
+		MorpheEntitiesConfig: cfg.MorpheEntitiesConfig{
+			Package: godef.Package{
+				Path: "github.com/kalo-build/project/domain/entities",
+				Name: "entities",
+			},
+			ReceiverName: "e",
+			ModelMappers: true,
+		},
+	}
+
+	r := registry.NewRegistry()
+	r.SetModel("Team", yaml.Model{
+		Name: "Team",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"User": {
+				Type: "HasMany",
+			},
+		},
+	})
+	r.SetModel("User", yaml.Model{
+		Name: "User",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Nickname": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+	})
+	r.SetEntity("Team", yaml.Entity{
+		Name: "Team",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "Team.ID",
+			},
+			"Nickname": {
+				Type: "Team.User.Nickname",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+	})
+
+	allMapperDefs, mappersErr := compile.AllMorpheEntityModelMappers(compile.MorpheCompileConfig{MorpheConfig: config}, r)
+
+	suite.ErrorContains(mappersErr, "entity Team field Nickname cannot be mapped through HasMany relation User")
+	suite.Nil(allMapperDefs)
+}
@@ -11,13 +11,22 @@ import (
 
 func AllMorpheEnumsToGoEnums(config MorpheCompileConfig, r *registry.Registry) (map[string]*godef.Enum, error) {
 	allEnumDefs := map[string]*godef.Enum{}
-	for enumName, enum := range r.GetAllEnums() {
+	allErrs := CompileErrors{}
+	allEnums := r.GetAllEnums()
+	for _, enumName := range core.MapKeysSorted(allEnums) {
+		enum := allEnums[enumName]
 		enumType, enumErr := MorpheEnumToGoEnum(config.EnumHooks, config.MorpheEnumsConfig, enum)
 		if enumErr != nil {
-			return nil, enumErr
+			if addErr := addCompileError(config, &allErrs, CompileErrorKindEnum, enumName, enumErr); addErr != nil {
+				return nil, addErr
+			}
+			continue
 		}
 		allEnumDefs[enumName] = enumType
 	}
+	if compileErrs := allErrs.Err(); compileErrs != nil {
+		return nil, compileErrs
+	}
 	return allEnumDefs, nil
 }
 
@@ -113,7 +122,7 @@ func getGoEntriesForMorpheEnum(enumName string, entries map[string]any) ([]godef
 	for _, entryName := range entryNames {
 		entryValue, entryExists := entries[entryName]
 		if !entryExists {
-			return nil, ErrEnumEntryNotFound(entryName)
+			return nil, ErrMorpheField(entryName, ErrEnumEntryNotFound(entryName))
 		}
 		goEntries = append(goEntries, godef.EnumEntry{
 			Name:  enumName + entryName,
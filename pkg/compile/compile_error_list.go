@@ -0,0 +1,165 @@
+package compile
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CompileErrorKind is the kind of Morphe definition a CompileError belongs to.
+type CompileErrorKind string
+
+const (
+	CompileErrorKindEnum      CompileErrorKind = "enum"
+	CompileErrorKindModel     CompileErrorKind = "model"
+	CompileErrorKindStructure CompileErrorKind = "structure"
+	CompileErrorKindEntity    CompileErrorKind = "entity"
+)
+
+// compileErrorKindOrder sorts the errors in compilation order.
+var compileErrorKindOrder = map[CompileErrorKind]int{
+	CompileErrorKindEnum:      0,
+	CompileErrorKindModel:     1,
+	CompileErrorKindStructure: 2,
+	CompileErrorKindEntity:    3,
+}
+
+// CompileError is the failure of a single Morphe definition. It is returned by the compile functions on the first failure,
+// or collected in CompileErrors when MorpheCompileConfig.AggregateErrors is enabled.
+type CompileError struct {
+	Kind CompileErrorKind
+	// Definition is the name of the failed definition, ie. "Person"
+	Definition string
+	// Field is the field, relation or enum entry that failed, if known
+	Field   string
+	Message string
+	// Position is the location of the field (or the definition) in the YAML registry, set by MorpheToGo
+	Position SourcePosition
+
+	Err error
+}
+
+// NewCompileError returns the CompileError of a definition, the field is taken from errors returned by ErrMorpheField.
+func NewCompileError(kind CompileErrorKind, definitionName string, err error) *CompileError {
+	compileErr := &CompileError{
+		Kind:       kind,
+		Definition: definitionName,
+		Message:    err.Error(),
+		Err:        err,
+	}
+	var fieldErr morpheFieldError
+	if errors.As(err, &fieldErr) {
+		compileErr.Field = fieldErr.fieldName
+	}
+	return compileErr
+}
+
+func (e *CompileError) Error() string {
+	message := fmt.Sprintf("%s '%s': %s", e.Kind, e.Definition, e.Message)
+	if e.Field != "" {
+		message = fmt.Sprintf("%s '%s' field '%s': %s", e.Kind, e.Definition, e.Field, e.Message)
+	}
+	if e.Position.IsEmpty() {
+		return message
+	}
+	return e.Position.String() + ": " + message
+}
+
+func (e *CompileError) Unwrap() error {
+	return e.Err
+}
+
+// CompileErrors are the failures of all definitions of a compilation, sorted by kind, definition, field and message.
+type CompileErrors []*CompileError
+
+func (errs CompileErrors) Error() string {
+	allLines := []string{
+		fmt.Sprintf("%d compile errors:", len(errs)),
+	}
+	for _, compileErr := range errs {
+		allLines = append(allLines, "  "+compileErr.Error())
+	}
+	return strings.Join(allLines, "\n")
+}
+
+func (errs CompileErrors) Unwrap() []error {
+	allErrs := make([]error, len(errs))
+	for errIdx, compileErr := range errs {
+		allErrs[errIdx] = compileErr
+	}
+	return allErrs
+}
+
+// Sort orders the errors deterministically, independent of the order the definitions were compiled in.
+func (errs CompileErrors) Sort() {
+	sort.SliceStable(errs, func(i, j int) bool {
+		errI, errJ := errs[i], errs[j]
+		if errI.Kind != errJ.Kind {
+			return compileErrorKindOrder[errI.Kind] < compileErrorKindOrder[errJ.Kind]
+		}
+		if errI.Definition != errJ.Definition {
+			return errI.Definition < errJ.Definition
+		}
+		if errI.Field != errJ.Field {
+			return errI.Field < errJ.Field
+		}
+		return errI.Message < errJ.Message
+	})
+}
+
+// Err returns the sorted errors, or nil if there are none.
+func (errs CompileErrors) Err() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	errs.Sort()
+	return errs
+}
+
+// addCompileError collects the error of a definition if errors are aggregated, otherwise it is returned as a CompileError.
+func addCompileError(config MorpheCompileConfig, allErrs *CompileErrors, kind CompileErrorKind, definitionName string, err error) error {
+	compileErr := NewCompileError(kind, definitionName, err)
+	if !config.AggregateErrors {
+		return compileErr
+	}
+	*allErrs = append(*allErrs, compileErr)
+	return nil
+}
+
+// addCompileErrors collects the CompileErrors of a whole section if errors are aggregated, other errors are returned as is.
+func addCompileErrors(config MorpheCompileConfig, allErrs *CompileErrors, err error) error {
+	if err == nil {
+		return nil
+	}
+	var compileErrs CompileErrors
+	if !config.AggregateErrors || !errors.As(err, &compileErrs) {
+		return err
+	}
+	*allErrs = append(*allErrs, compileErrs...)
+	return nil
+}
+
+// setCompileErrorPositions sets the registry positions of a CompileError or CompileErrors. The positions are only read
+// from the YAML files once compilation failed, and are left empty if the files can't be read.
+func setCompileErrorPositions(config MorpheCompileConfig, err error) {
+	allCompileErrs := CompileErrors{}
+	var compileErrs CompileErrors
+	var compileErr *CompileError
+	if errors.As(err, &compileErrs) {
+		allCompileErrs = compileErrs
+	} else if errors.As(err, &compileErr) {
+		allCompileErrs = append(allCompileErrs, compileErr)
+	}
+	if len(allCompileErrs) == 0 {
+		return
+	}
+
+	positions, positionsErr := LoadSourcePositions(config.MorpheLoadRegistryConfig)
+	if positionsErr != nil {
+		return
+	}
+	for _, definitionErr := range allCompileErrs {
+		definitionErr.Position = positions.GetPosition(definitionErr.Kind, definitionErr.Definition, definitionErr.Field)
+	}
+}
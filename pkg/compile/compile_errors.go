@@ -8,11 +8,35 @@ import (
 )
 
 var ErrNoRegistry = errors.New("registry not initialized")
+var ErrWriterCheckUnsupported = errors.New("configured writer does not support check mode")
+var ErrWriterSourceUnsupported = errors.New("configured writer does not support pre-rendered definitions")
 
 func ErrUnsupportedMorpheFieldType[TType yaml.ModelFieldType | yaml.StructureFieldType](unsupportedType TType) error {
 	return fmt.Errorf("unsupported morphe field type for go conversion: '%s'", unsupportedType)
 }
 
+// ErrMorpheField attributes an error to a field (or relation, enum entry) of the compiled definition, see CompileError.Field.
+// The error message is left unchanged.
+func ErrMorpheField(fieldName string, err error) error {
+	return morpheFieldError{
+		fieldName: fieldName,
+		err:       err,
+	}
+}
+
+type morpheFieldError struct {
+	fieldName string
+	err       error
+}
+
+func (e morpheFieldError) Error() string {
+	return e.err.Error()
+}
+
+func (e morpheFieldError) Unwrap() error {
+	return e.err
+}
+
 func ErrMissingMorpheIdentifierField(modelName string, identifierName string, fieldName string) error {
 	return fmt.Errorf("morphe model '%s' has no field '%s' referenced in identifiers ('%s')", modelName, identifierName, fieldName)
 }
@@ -1,6 +1,17 @@
 package compile
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var ErrNoModelStructs = errors.New("no model structs provided")
 var ErrNoModelStruct = errors.New("no model struct provided")
+
+func ErrPolyRelationTargetsConflict(relationName string, modelName string, otherModelName string) error {
+	return fmt.Errorf("polymorphic relation '%s' of models '%s' and '%s' must link to the same models", relationName, modelName, otherModelName)
+}
+
+func ErrPolyRelationModelConflict(modelName string, relationName string) error {
+	return fmt.Errorf("polymorphic relation '%s' of model '%s' conflicts with the model of the same name", relationName, modelName)
+}
@@ -0,0 +1,342 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/go-util/strcase"
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/morphe-go/pkg/registry"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/morphe-go/pkg/yamlops"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+)
+
+// polyRelation is a polymorphic For* relation (ForOnePoly / ForManyPoly) shared by all models declaring it under the same name.
+type polyRelation struct {
+	Name        string
+	ModelName   string
+	TargetNames []string
+}
+
+// AllMorpheModelPolyDefinitions returns the rendered contents of the shared declarations of all polymorphic For* relations
+// (definition name -> contents) if typed polymorphic relations are enabled, ie. the "CommentableType" enum and the "Commentable" interface.
+func AllMorpheModelPolyDefinitions(config MorpheCompileConfig, r *registry.Registry) (map[string]string, error) {
+	allPolyDefs := map[string]string{}
+	if !config.MorpheModelsConfig.TypedPolyRelations {
+		return allPolyDefs, nil
+	}
+	if r == nil {
+		return nil, ErrNoRegistry
+	}
+
+	allModels := r.GetAllModels()
+	allPolyRelations := map[string]polyRelation{}
+	allErrs := CompileErrors{}
+	for _, modelName := range core.MapKeysSorted(allModels) {
+		polyErr := addModelPolyRelations(allPolyRelations, allModels, allModels[modelName])
+		if polyErr != nil {
+			if addErr := addCompileError(config, &allErrs, CompileErrorKindModel, modelName, polyErr); addErr != nil {
+				return nil, addErr
+			}
+		}
+	}
+	if compileErrs := allErrs.Err(); compileErrs != nil {
+		return nil, compileErrs
+	}
+
+	for relationName, relation := range allPolyRelations {
+		allPolyDefs[relationName] = getModelPolyFileContents(config.MorpheModelsConfig, relation)
+	}
+	return allPolyDefs, nil
+}
+
+// getAllModelPolyRelations collects all polymorphic For* relations in the registry by relation name. The same relation name can
+// be used by several models as long as they link to the same models, since the shared declarations are named after the relation.
+func getAllModelPolyRelations(r *registry.Registry) (map[string]polyRelation, error) {
+	allModels := r.GetAllModels()
+	allPolyRelations := map[string]polyRelation{}
+	for _, modelName := range core.MapKeysSorted(allModels) {
+		polyErr := addModelPolyRelations(allPolyRelations, allModels, allModels[modelName])
+		if polyErr != nil {
+			return nil, polyErr
+		}
+	}
+	return allPolyRelations, nil
+}
+
+// addModelPolyRelations adds the polymorphic For* relations of a model to the relations collected so far.
+func addModelPolyRelations(allPolyRelations map[string]polyRelation, allModels map[string]yaml.Model, model yaml.Model) error {
+	for _, relationName := range core.MapKeysSorted(model.Related) {
+		relationDef := model.Related[relationName]
+		if !isModelPolyForRelation(relationDef) {
+			continue
+		}
+
+		if len(relationDef.For) == 0 {
+			return fmt.Errorf("polymorphic relation '%s' must have at least one model in 'for' property", relationName)
+		}
+
+		relation := polyRelation{
+			Name:        relationName,
+			ModelName:   model.Name,
+			TargetNames: getSortedPolyTargetNames(relationDef),
+		}
+		if _, isModelName := allModels[relationName]; isModelName {
+			return ErrPolyRelationModelConflict(model.Name, relationName)
+		}
+		for _, targetName := range relation.TargetNames {
+			if _, targetExists := allModels[targetName]; !targetExists {
+				return fmt.Errorf("polymorphic relation '%s' of model '%s' references unknown model '%s'", relationName, model.Name, targetName)
+			}
+		}
+
+		existingRelation, relationExists := allPolyRelations[relationName]
+		if relationExists && !slices.Equal(existingRelation.TargetNames, relation.TargetNames) {
+			return ErrPolyRelationTargetsConflict(relationName, existingRelation.ModelName, model.Name)
+		}
+		if !relationExists {
+			allPolyRelations[relationName] = relation
+		}
+	}
+	return nil
+}
+
+// getModelPolyTargetNames returns the sorted target models of the polymorphic For* relations of a model by relation name,
+// which is also the name of the marker interface field.
+func getModelPolyTargetNames(model yaml.Model) map[string][]string {
+	allPolyTargetNames := map[string][]string{}
+	for relationName, relationDef := range model.Related {
+		if isModelPolyForRelation(relationDef) {
+			allPolyTargetNames[relationName] = getSortedPolyTargetNames(relationDef)
+		}
+	}
+	return allPolyTargetNames
+}
+
+func isModelPolyForRelation(relationDef yaml.ModelRelation) bool {
+	return yamlops.IsRelationPoly(relationDef.Type) && yamlops.IsRelationFor(relationDef.Type)
+}
+
+func getSortedPolyTargetNames(relationDef yaml.ModelRelation) []string {
+	targetNames := make([]string, len(relationDef.For))
+	copy(targetNames, relationDef.For)
+	sort.Strings(targetNames)
+	return targetNames
+}
+
+func getModelPolyFileContents(config cfg.MorpheModelsConfig, relation polyRelation) string {
+	typeName := getPolyTypeName(relation.Name)
+
+	allLines := []string{
+		gofile.GeneratedFileHeader,
+		"",
+		fmt.Sprintf("package %s", config.Package.Name),
+		"",
+		fmt.Sprintf("// %s is the type of a model linked through a polymorphic %s relation.", typeName, relation.Name),
+		fmt.Sprintf("type %s string", typeName),
+		"",
+		"const (",
+	}
+	for _, targetName := range relation.TargetNames {
+		allLines = append(allLines, fmt.Sprintf("\t%s %s = %q", getPolyTypeEntryName(relation.Name, targetName), typeName, targetName))
+	}
+	allLines = append(allLines, ")", "")
+
+	allLines = append(allLines,
+		fmt.Sprintf("// IsValid returns true if the value is one of the models linked through %s.", relation.Name),
+		fmt.Sprintf("func (t %s) IsValid() bool {", typeName),
+		"\tswitch t {",
+	)
+	allEntryNames := []string{}
+	for _, targetName := range relation.TargetNames {
+		allEntryNames = append(allEntryNames, getPolyTypeEntryName(relation.Name, targetName))
+	}
+	allLines = append(allLines,
+		fmt.Sprintf("\tcase %s:", strings.Join(allEntryNames, ", ")),
+		"\t\treturn true",
+		"\t}",
+		"\treturn false",
+		"}",
+		"",
+	)
+
+	allLines = append(allLines,
+		fmt.Sprintf("// %s is implemented by all models that can be linked through a polymorphic %s relation.", relation.Name, relation.Name),
+		fmt.Sprintf("type %s interface {", relation.Name),
+		fmt.Sprintf("\t%s() %s", getPolyTypeMethodName(relation.Name), typeName),
+		fmt.Sprintf("\t%s() string", getPolyIDMethodName(relation.Name)),
+		"}",
+		"",
+	)
+
+	fileContents, _ := core.LinesToString(allLines)
+	return fileContents
+}
+
+// getModelPolyFields returns the fields of a polymorphic For* relation if typed polymorphic relations are enabled:
+// the type field is typed with the closed type enum and the linked model is held by the marker interface.
+func getModelPolyFields(config cfg.MorpheModelsConfig, relationshipName string, relationDef yaml.ModelRelation, fieldCasing cfg.Casing) []godef.StructField {
+	typeFieldName := relationshipName + "Type"
+	idFieldName := relationshipName + "ID"
+	return []godef.StructField{
+		{
+			Name: typeFieldName,
+			Type: godef.GoTypeDerived{
+				Name:     getPolyTypeName(relationshipName),
+				BaseType: godef.GoTypeString,
+			},
+			Tags: getModelPolyTypeFieldTags(config, typeFieldName, relationDef, fieldCasing),
+		},
+		{
+			Name: idFieldName,
+			Type: godef.GoTypeString,
+			Tags: getModelPolyIDFieldTags(config, idFieldName, relationDef, fieldCasing),
+		},
+		{
+			Name: relationshipName,
+			Type: godef.GoTypeInterface{
+				Name: relationshipName,
+			},
+			// The marker interface can't be unmarshalled, the link itself is serialized through the type and ID fields
+			Tags: append([]string{`json:"-"`}, buildORMFieldTags(config.ORMTags, relationshipName, ormField{NotColumn: true})...),
+		},
+	}
+}
+
+// addModelPolyMethods adds the typed helpers of all polymorphic For* relations of the model (ie. "SetCommentable" and
+// "CommentableAsPerson") to the model struct, as well as the marker methods of all polymorphic relations linking to the model.
+func addModelPolyMethods(config cfg.MorpheModelsConfig, r *registry.Registry, model yaml.Model, modelStruct *godef.Struct) error {
+	receiverType := godef.GoTypeStruct{
+		PackagePath: config.Package.Path,
+		Name:        model.Name,
+	}
+
+	for _, relationName := range core.MapKeysSorted(model.Related) {
+		relationDef := model.Related[relationName]
+		if !isModelPolyForRelation(relationDef) {
+			continue
+		}
+		modelStruct.Methods = append(modelStruct.Methods, getModelPolySetter(config, receiverType, relationName))
+		for _, targetName := range getSortedPolyTargetNames(relationDef) {
+			modelStruct.Methods = append(modelStruct.Methods, getModelPolyTargetGetter(config, receiverType, relationName, targetName))
+		}
+	}
+
+	allPolyRelations, polyErr := getAllModelPolyRelations(r)
+	if polyErr != nil {
+		return polyErr
+	}
+	for _, relationName := range core.MapKeysSorted(allPolyRelations) {
+		relation := allPolyRelations[relationName]
+		if !slices.Contains(relation.TargetNames, model.Name) {
+			continue
+		}
+		primaryIDFieldName, primaryIDErr := yamlops.GetModelPrimaryIdentifierFieldName(model)
+		if primaryIDErr != nil {
+			return fmt.Errorf("polymorphic relation '%s' target %w", relationName, primaryIDErr)
+		}
+		modelStruct.Methods = append(modelStruct.Methods, getModelPolyMarkerMethods(config, receiverType, relationName, model.Name, primaryIDFieldName)...)
+		// The marker ID method formats the primary identifier
+		modelStruct.Imports = addStructImport(modelStruct.Imports, "fmt")
+	}
+	return nil
+}
+
+func getModelPolySetter(config cfg.MorpheModelsConfig, receiverType godef.GoTypeStruct, relationName string) godef.StructMethod {
+	receiverName := config.ReceiverName
+	paramName := strcase.ToCamelCase(relationName)
+	return godef.StructMethod{
+		ReceiverName: receiverName,
+		ReceiverType: godef.GoTypePointer{ValueType: receiverType},
+		Name:         "Set" + relationName,
+		Parameters: map[string]godef.GoType{
+			paramName: godef.GoTypeInterface{Name: relationName},
+		},
+		BodyLines: []string{
+			fmt.Sprintf("\t%s.%sType = %s.%s()", receiverName, relationName, paramName, getPolyTypeMethodName(relationName)),
+			fmt.Sprintf("\t%s.%sID = %s.%s()", receiverName, relationName, paramName, getPolyIDMethodName(relationName)),
+			fmt.Sprintf("\t%s.%s = %s", receiverName, relationName, paramName),
+		},
+	}
+}
+
+func getModelPolyTargetGetter(config cfg.MorpheModelsConfig, receiverType godef.GoTypeStruct, relationName string, targetName string) godef.StructMethod {
+	receiverName := config.ReceiverName
+	targetVarName := strcase.ToCamelCase(targetName)
+	return godef.StructMethod{
+		ReceiverName: receiverName,
+		ReceiverType: receiverType,
+		Name:         fmt.Sprintf("%sAs%s", relationName, targetName),
+		ReturnTypes: []godef.GoType{
+			godef.GoTypeStruct{
+				PackagePath: config.Package.Path,
+				Name:        targetName,
+			},
+			godef.GoTypeBool,
+		},
+		// The marker methods have value receivers, so SetCommentable accepts both Person and *Person
+		BodyLines: []string{
+			fmt.Sprintf("\tswitch %s := %s.%s.(type) {", targetVarName, receiverName, relationName),
+			fmt.Sprintf("\tcase %s:", targetName),
+			fmt.Sprintf("\t\treturn %s, true", targetVarName),
+			fmt.Sprintf("\tcase *%s:", targetName),
+			fmt.Sprintf("\t\tif %s != nil {", targetVarName),
+			fmt.Sprintf("\t\t\treturn *%s, true", targetVarName),
+			"\t\t}",
+			"\t}",
+			fmt.Sprintf("\treturn %s{}, false", targetName),
+		},
+	}
+}
+
+func getModelPolyMarkerMethods(config cfg.MorpheModelsConfig, receiverType godef.GoTypeStruct, relationName string, modelName string, primaryIDFieldName string) []godef.StructMethod {
+	receiverName := config.ReceiverName
+	return []godef.StructMethod{
+		{
+			ReceiverName: receiverName,
+			ReceiverType: receiverType,
+			Name:         getPolyTypeMethodName(relationName),
+			ReturnTypes: []godef.GoType{
+				godef.GoTypeDerived{
+					Name:     getPolyTypeName(relationName),
+					BaseType: godef.GoTypeString,
+				},
+			},
+			BodyLines: []string{
+				fmt.Sprintf("\treturn %s", getPolyTypeEntryName(relationName, modelName)),
+			},
+		},
+		{
+			ReceiverName: receiverName,
+			ReceiverType: receiverType,
+			Name:         getPolyIDMethodName(relationName),
+			ReturnTypes: []godef.GoType{
+				godef.GoTypeString,
+			},
+			BodyLines: []string{
+				fmt.Sprintf("\treturn fmt.Sprint(%s.%s)", receiverName, primaryIDFieldName),
+			},
+		},
+	}
+}
+
+func getPolyTypeName(relationName string) string {
+	return relationName + "Type"
+}
+
+func getPolyTypeEntryName(relationName string, targetName string) string {
+	return getPolyTypeName(relationName) + targetName
+}
+
+func getPolyTypeMethodName(relationName string) string {
+	return strcase.ToCamelCase(relationName) + "Type"
+}
+
+func getPolyIDMethodName(relationName string) string {
+	return strcase.ToCamelCase(relationName) + "ID"
+}
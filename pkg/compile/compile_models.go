@@ -2,6 +2,7 @@ package compile
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -14,18 +15,26 @@ import (
 	"github.com/kalo-build/morphe-go/pkg/yamlops"
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/hook"
-	"github.com/kalo-build/plugin-morphe-go-struct/pkg/typemap"
 )
 
 func AllMorpheModelsToGoStructs(config MorpheCompileConfig, r *registry.Registry) (map[string][]*godef.Struct, error) {
 	allModelStructDefs := map[string][]*godef.Struct{}
-	for modelName, model := range r.GetAllModels() {
+	allErrs := CompileErrors{}
+	allModels := r.GetAllModels()
+	for _, modelName := range core.MapKeysSorted(allModels) {
+		model := allModels[modelName]
 		modelStructs, modelErr := MorpheModelToGoStructs(config, r, model)
 		if modelErr != nil {
-			return nil, modelErr
+			if addErr := addCompileError(config, &allErrs, CompileErrorKindModel, modelName, modelErr); addErr != nil {
+				return nil, addErr
+			}
+			continue
 		}
 		allModelStructDefs[modelName] = modelStructs
 	}
+	if compileErrs := allErrs.Err(); compileErrs != nil {
+		return nil, compileErrs
+	}
 	return allModelStructDefs, nil
 }
 
@@ -77,6 +86,35 @@ func morpheModelToGoStructs(config cfg.MorpheConfig, r *registry.Registry, model
 		return nil, identifierErr
 	}
 	allModelStructs = append(allModelStructs, identifierStructs...)
+
+	if config.MorpheModelsConfig.TypedPolyRelations {
+		polyErr := addModelPolyMethods(config.MorpheModelsConfig, r, model, modelStruct)
+		if polyErr != nil {
+			return nil, polyErr
+		}
+	}
+
+	if config.MorpheModelsConfig.Constructors {
+		withErr := addModelWithMethods(config.MorpheModelsConfig, r, model, modelStruct)
+		if withErr != nil {
+			return nil, withErr
+		}
+	}
+
+	if config.MorpheModelsConfig.ValidateMethods {
+		validateChecks := getModelValidateChecks(config, r.GetAllEnums(), model)
+		modelStruct.Methods = append(modelStruct.Methods, getValidateMethod(config.MorpheModelsConfig.Package, config.MorpheModelsConfig.ReceiverName, model.Name, validateChecks))
+	}
+	if config.MorpheModelsConfig.CloneMethods {
+		allPolyTargetNames := map[string][]string{}
+		if config.MorpheModelsConfig.TypedPolyRelations {
+			allPolyTargetNames = getModelPolyTargetNames(model)
+		}
+		modelStruct.Methods = append(modelStruct.Methods, getCloneMethods(config.MorpheModelsConfig.Package, config.MorpheModelsConfig.ReceiverName, model.Name, modelStruct.Fields, allPolyTargetNames)...)
+	}
+	if config.MorpheModelsConfig.DiffMethods {
+		modelStruct.Methods = append(modelStruct.Methods, getDiffMethods(config.MorpheModelsConfig.Package, config.MorpheTypeOverridesConfig, config.MorpheModelsConfig.ReceiverName, model.Name, modelStruct.Fields)...)
+	}
 	return allModelStructs, nil
 }
 
@@ -89,13 +127,19 @@ func getModelStruct(config cfg.MorpheConfig, r *registry.Registry, model yaml.Mo
 		Package: config.MorpheModelsConfig.Package,
 		Name:    model.Name,
 	}
-	structFields, fieldsErr := getGoFieldsForMorpheModel(config.MorpheEnumsConfig.Package, r, model, config.MorpheModelsConfig.FieldCasing)
+	structFields, fieldsErr := getGoFieldsForMorpheModel(config, r, model, config.MorpheModelsConfig.FieldCasing)
 	if fieldsErr != nil {
 		return nil, fieldsErr
 	}
+	applyJSONTagsConfig(config.MorpheModelsConfig.JSONTags, model.Name, structFields, getRelationFieldNames(model.Related))
 	modelStruct.Fields = structFields
 
-	structImports, importsErr := getImportsForStructFields(config.MorpheModelsConfig.Package, structFields)
+	importFields := structFields
+	if idType, hasIDType := findStructIDType(&modelStruct); hasIDType {
+		// The ID type is declared in the model file, so its underlying type needs to be imported there
+		importFields = append(importFields, godef.StructField{Type: idType.BaseType})
+	}
+	structImports, importsErr := getImportsForStructFields(config.MorpheModelsConfig.Package, importFields)
 	if importsErr != nil {
 		return nil, importsErr
 	}
@@ -104,13 +148,16 @@ func getModelStruct(config cfg.MorpheConfig, r *registry.Registry, model yaml.Mo
 	return &modelStruct, nil
 }
 
-func getGoFieldsForMorpheModel(enumPackage godef.Package, r *registry.Registry, model yaml.Model, fieldCasing cfg.Casing) ([]godef.StructField, error) {
-	allFields, fieldErr := getDirectGoFieldsForMorpheModel(enumPackage, r.GetAllEnums(), model.Fields, fieldCasing)
+func getGoFieldsForMorpheModel(config cfg.MorpheConfig, r *registry.Registry, model yaml.Model, fieldCasing cfg.Casing) ([]godef.StructField, error) {
+	// Models without a single field primary identifier don't get a named ID type
+	primaryIDFieldName, _ := yamlops.GetModelPrimaryIdentifierFieldName(model)
+
+	allFields, fieldErr := getDirectGoFieldsForMorpheModel(config, r.GetAllEnums(), model, primaryIDFieldName, fieldCasing)
 	if fieldErr != nil {
 		return nil, fieldErr
 	}
 
-	allRelatedFields, relatedErr := getRelatedGoFieldsForMorpheModel(r, model.Related, fieldCasing)
+	allRelatedFields, relatedErr := getRelatedGoFieldsForMorpheModel(config, r, model, primaryIDFieldName, fieldCasing)
 	if relatedErr != nil {
 		return nil, relatedErr
 	}
@@ -119,33 +166,52 @@ func getGoFieldsForMorpheModel(enumPackage godef.Package, r *registry.Registry,
 	return allFields, nil
 }
 
-func getDirectGoFieldsForMorpheModel(enumPackage godef.Package, allEnums map[string]yaml.Enum, modelFields map[string]yaml.ModelField, fieldCasing cfg.Casing) ([]godef.StructField, error) {
+func getDirectGoFieldsForMorpheModel(config cfg.MorpheConfig, allEnums map[string]yaml.Enum, model yaml.Model, primaryIDFieldName string, fieldCasing cfg.Casing) ([]godef.StructField, error) {
 	allFields := []godef.StructField{}
+	modelName := model.Name
+	modelFields := model.Fields
+	ormTagsConfig := config.MorpheModelsConfig.ORMTags
+	primaryFieldNames := model.Identifiers["primary"].Fields
 
-	allFieldNames := core.MapKeysSorted(modelFields)
+	allFieldNames := getOrderedFieldNames(config.MorpheModelsConfig.FieldOrder, config.DeclarationOrders.Models[modelName], modelFields, wrapModelIdentifiers(model.Identifiers))
 	for _, fieldName := range allFieldNames {
 		fieldDef := modelFields[fieldName]
 
-		goEnumField := getEnumFieldAsStructFieldType(enumPackage, allEnums, fieldName, string(fieldDef.Type), fieldCasing)
-		if goEnumField.Name != "" && goEnumField.Type != nil {
-			if hasAttribute(fieldDef.Attributes, "optional") {
+		_, hasOverride := config.MorpheTypeOverridesConfig.GetModelFieldOverride(modelName, fieldName, string(fieldDef.Type))
+		goEnumField := getEnumFieldAsStructFieldType(config.MorpheEnumsConfig.Package, allEnums, fieldName, string(fieldDef.Type), fieldCasing)
+		ormField := getORMDirectField(fieldName, fieldDef.Type, fieldDef.Attributes, primaryFieldNames)
+		isOptional := hasAttribute(fieldDef.Attributes, "optional")
+		validateRules := getValidateFieldRules(string(fieldDef.Type), isOptional, hasOverride, allEnums)
+		if !hasOverride && goEnumField.Name != "" && goEnumField.Type != nil {
+			if isOptional {
 				goEnumField.Type = godef.GoTypePointer{ValueType: goEnumField.Type}
 			}
+			goEnumField.Tags = append(goEnumField.Tags, buildORMFieldTags(ormTagsConfig, fieldName, ormField)...)
+			if config.MorpheModelsConfig.ValidateTags {
+				goEnumField.Tags = append(goEnumField.Tags, buildValidateFieldTags(validateRules, isOptional)...)
+			}
 			allFields = append(allFields, goEnumField)
 			continue
 		}
 
-		goFieldType, typeSupported := typemap.MorpheModelFieldToGoField[fieldDef.Type]
+		goFieldType, typeSupported := getModelFieldGoType(config, modelName, fieldName, fieldDef.Type)
 		if !typeSupported {
-			return nil, ErrUnsupportedMorpheFieldType(fieldDef.Type)
+			return nil, ErrMorpheField(fieldName, ErrUnsupportedMorpheFieldType(fieldDef.Type))
+		}
+		if fieldName == primaryIDFieldName {
+			goFieldType = getModelIDGoType(config, modelName, goFieldType)
 		}
 
 		// Model fields are required by default; wrap in pointer for "optional" attribute
-		if hasAttribute(fieldDef.Attributes, "optional") {
+		if isOptional {
 			goFieldType = godef.GoTypePointer{ValueType: goFieldType}
 		}
 
 		tags := buildFieldTags(fieldName, fieldDef.Attributes, fieldCasing)
+		tags = append(tags, buildORMFieldTags(ormTagsConfig, fieldName, ormField)...)
+		if config.MorpheModelsConfig.ValidateTags {
+			tags = append(tags, buildValidateFieldTags(validateRules, isOptional)...)
+		}
 
 		goField := godef.StructField{
 			Name: fieldName,
@@ -185,10 +251,12 @@ func buildFieldTags(fieldName string, attributes []string, fieldCasing cfg.Casin
 	return tags
 }
 
-func getRelatedGoFieldsForMorpheModel(r *registry.Registry, modelRelations map[string]yaml.ModelRelation, fieldCasing cfg.Casing) ([]godef.StructField, error) {
+func getRelatedGoFieldsForMorpheModel(config cfg.MorpheConfig, r *registry.Registry, model yaml.Model, primaryIDFieldName string, fieldCasing cfg.Casing) ([]godef.StructField, error) {
 	allFields := []godef.StructField{}
+	modelRelations := model.Related
+	ormTagsConfig := config.MorpheModelsConfig.ORMTags
 
-	allRelatedModelNames := core.MapKeysSorted(modelRelations)
+	allRelatedModelNames := getOrderedRelationNames(config.MorpheModelsConfig.FieldOrder, config.DeclarationOrders.Models[model.Name], modelRelations)
 	for _, relationshipName := range allRelatedModelNames {
 		relationDef := modelRelations[relationshipName]
 
@@ -197,7 +265,12 @@ func getRelatedGoFieldsForMorpheModel(r *registry.Registry, modelRelations map[s
 		if yamlops.IsRelationPoly(relationDef.Type) && yamlops.IsRelationFor(relationDef.Type) {
 			// Validate that For property is provided and has at least one model
 			if len(relationDef.For) == 0 {
-				return nil, fmt.Errorf("polymorphic relation '%s' must have at least one model in 'for' property", relationshipName)
+				return nil, ErrMorpheField(relationshipName, fmt.Errorf("polymorphic relation '%s' must have at least one model in 'for' property", relationshipName))
+			}
+
+			if config.MorpheModelsConfig.TypedPolyRelations {
+				allFields = append(allFields, getModelPolyFields(config.MorpheModelsConfig, relationshipName, relationDef, fieldCasing)...)
+				continue
 			}
 
 			// Generate polymorphic type field
@@ -205,7 +278,7 @@ func getRelatedGoFieldsForMorpheModel(r *registry.Registry, modelRelations map[s
 			typeField := godef.StructField{
 				Name: typeFieldName,
 				Type: godef.GoTypeString,
-				Tags: buildFieldTags(typeFieldName, nil, fieldCasing),
+				Tags: getModelPolyTypeFieldTags(config.MorpheModelsConfig, typeFieldName, relationDef, fieldCasing),
 			}
 			allFields = append(allFields, typeField)
 
@@ -214,7 +287,7 @@ func getRelatedGoFieldsForMorpheModel(r *registry.Registry, modelRelations map[s
 			idField := godef.StructField{
 				Name: idFieldName,
 				Type: godef.GoTypeString,
-				Tags: buildFieldTags(idFieldName, nil, fieldCasing),
+				Tags: getModelPolyIDFieldTags(config.MorpheModelsConfig, idFieldName, relationDef, fieldCasing),
 			}
 			allFields = append(allFields, idField)
 
@@ -234,34 +307,34 @@ func getRelatedGoFieldsForMorpheModel(r *registry.Registry, modelRelations map[s
 				// Get the target model to validate the Through relationship exists
 				relatedModelDef, relatedModelDefErr := r.GetModel(targetModelName)
 				if relatedModelDefErr != nil {
-					return nil, relatedModelDefErr
+					return nil, ErrMorpheField(relationshipName, relatedModelDefErr)
 				}
 
 				// Check if the Through relationship exists on the target model
 				throughRelation, throughExists := relatedModelDef.Related[relationDef.Through]
 				if !throughExists {
-					return nil, fmt.Errorf("polymorphic relation '%s' has invalid 'through' property: relation '%s' not found on model '%s'", relationshipName, relationDef.Through, targetModelName)
+					return nil, ErrMorpheField(relationshipName, fmt.Errorf("polymorphic relation '%s' has invalid 'through' property: relation '%s' not found on model '%s'", relationshipName, relationDef.Through, targetModelName))
 				}
 
 				// Verify the Through relationship is a polymorphic For* relationship
 				if !yamlops.IsRelationPoly(throughRelation.Type) || !yamlops.IsRelationFor(throughRelation.Type) {
-					return nil, fmt.Errorf("polymorphic relation '%s' has invalid 'through' property: relation '%s' must be a polymorphic For* relationship", relationshipName, relationDef.Through)
+					return nil, ErrMorpheField(relationshipName, fmt.Errorf("polymorphic relation '%s' has invalid 'through' property: relation '%s' must be a polymorphic For* relationship", relationshipName, relationDef.Through))
 				}
 			}
 
 			relatedModelDef, relatedModelDefErr := r.GetModel(targetModelName)
 			if relatedModelDefErr != nil {
-				return nil, relatedModelDefErr
+				return nil, ErrMorpheField(relationshipName, relatedModelDefErr)
 			}
 
-			goIDField, goIDErr := getRelatedGoFieldForMorpheModelPrimaryID(relationshipName, targetModelName, relatedModelDef, relationDef, fieldCasing)
+			goIDField, goIDErr := getRelatedGoFieldForMorpheModelPrimaryID(config, relationshipName, targetModelName, relatedModelDef, relationDef, fieldCasing)
 			if goIDErr != nil {
-				return nil, goIDErr
+				return nil, ErrMorpheField(relationshipName, goIDErr)
 			}
-			allFields = append(allFields, goIDField)
-
 			goRelatedField := getRelatedGoFieldForMorpheModel(relationshipName, targetModelName, relationDef, fieldCasing)
-			allFields = append(allFields, goRelatedField)
+			addModelRelatedORMTags(ormTagsConfig, model, primaryIDFieldName, relationshipName, relationDef, relatedModelDef, &goIDField, &goRelatedField)
+			addModelRelatedValidateTags(config, relationDef, relatedModelDef, &goIDField, &goRelatedField)
+			allFields = append(allFields, goIDField, goRelatedField)
 			continue
 		}
 
@@ -279,22 +352,22 @@ func getRelatedGoFieldsForMorpheModel(r *registry.Registry, modelRelations map[s
 
 		relatedModelDef, relatedModelDefErr := r.GetModel(targetModelName)
 		if relatedModelDefErr != nil {
-			return nil, fmt.Errorf("failed to get model '%s' for relation '%s': %w", targetModelName, relationshipName, relatedModelDefErr)
+			return nil, ErrMorpheField(relationshipName, fmt.Errorf("failed to get model '%s' for relation '%s': %w", targetModelName, relationshipName, relatedModelDefErr))
 		}
 
-		goIDField, goIDErr := getRelatedGoFieldForMorpheModelPrimaryID(relationshipName, targetModelName, relatedModelDef, relationDef, fieldCasing)
+		goIDField, goIDErr := getRelatedGoFieldForMorpheModelPrimaryID(config, relationshipName, targetModelName, relatedModelDef, relationDef, fieldCasing)
 		if goIDErr != nil {
-			return nil, goIDErr
+			return nil, ErrMorpheField(relationshipName, goIDErr)
 		}
-		allFields = append(allFields, goIDField)
-
 		goRelatedField := getRelatedGoFieldForMorpheModel(relationshipName, targetModelName, relationDef, fieldCasing)
-		allFields = append(allFields, goRelatedField)
+		addModelRelatedORMTags(ormTagsConfig, model, primaryIDFieldName, relationshipName, relationDef, relatedModelDef, &goIDField, &goRelatedField)
+		addModelRelatedValidateTags(config, relationDef, relatedModelDef, &goIDField, &goRelatedField)
+		allFields = append(allFields, goIDField, goRelatedField)
 	}
 	return allFields, nil
 }
 
-func getRelatedGoFieldForMorpheModelPrimaryID(relationshipName, targetModelName string, relatedModelDef yaml.Model, relationDef yaml.ModelRelation, fieldCasing cfg.Casing) (godef.StructField, error) {
+func getRelatedGoFieldForMorpheModelPrimaryID(config cfg.MorpheConfig, relationshipName, targetModelName string, relatedModelDef yaml.Model, relationDef yaml.ModelRelation, fieldCasing cfg.Casing) (godef.StructField, error) {
 	relatedPrimaryIDFieldName, relatedIDFieldNameErr := yamlops.GetModelPrimaryIdentifierFieldName(relatedModelDef)
 	if relatedIDFieldNameErr != nil {
 		return godef.StructField{}, fmt.Errorf("related %w", relatedIDFieldNameErr)
@@ -311,10 +384,11 @@ func getRelatedGoFieldForMorpheModelPrimaryID(relationshipName, targetModelName
 		return godef.StructField{}, fmt.Errorf("related %w (primary identifier)", relatedIDFieldDefErr)
 	}
 
-	idFieldType, typeSupported := typemap.MorpheModelFieldToGoField[relatedPrimaryIDFieldDef.Type]
+	idFieldType, typeSupported := getModelFieldGoType(config, relatedModelDef.Name, relatedPrimaryIDFieldName, relatedPrimaryIDFieldDef.Type)
 	if !typeSupported {
 		return godef.StructField{}, ErrUnsupportedMorpheFieldType(relatedPrimaryIDFieldDef.Type)
 	}
+	idFieldType = getModelIDGoType(config, relatedModelDef.Name, idFieldType)
 
 	if yamlops.IsRelationMany(relationDef.Type) {
 		return godef.StructField{
@@ -338,6 +412,56 @@ func getRelatedGoFieldForMorpheModelPrimaryID(relationshipName, targetModelName
 	}, nil
 }
 
+// addModelRelatedORMTags appends the enabled ORM tags to the related ID field and the field holding the related model(s) of a relation.
+func addModelRelatedORMTags(config cfg.ORMTagsConfig, model yaml.Model, primaryIDFieldName string, relationshipName string, relationDef yaml.ModelRelation, relatedModelDef yaml.Model, goIDField *godef.StructField, goRelatedField *godef.StructField) {
+	if !config.IsEnabled() {
+		return
+	}
+	relatedPrimaryIDFieldName, _ := yamlops.GetModelPrimaryIdentifierFieldName(relatedModelDef)
+
+	goIDField.Tags = append(goIDField.Tags, buildORMFieldTags(config, goIDField.Name, getORMRelatedIDField(relationDef))...)
+
+	relatedORMField := getORMRelatedField(model.Name, primaryIDFieldName, relationshipName, relationDef, goIDField.Name, relatedModelDef.Name, relatedPrimaryIDFieldName, relatedModelDef.Related)
+	goRelatedField.Tags = append(goRelatedField.Tags, buildORMFieldTags(config, goRelatedField.Name, relatedORMField)...)
+}
+
+// addModelRelatedValidateTags appends the validate tags to the related ID field and the field holding the related model(s) of a relation.
+func addModelRelatedValidateTags(config cfg.MorpheConfig, relationDef yaml.ModelRelation, relatedModelDef yaml.Model, goIDField *godef.StructField, goRelatedField *godef.StructField) {
+	if !config.MorpheModelsConfig.ValidateTags {
+		return
+	}
+	relatedPrimaryIDFieldName, _ := yamlops.GetModelPrimaryIdentifierFieldName(relatedModelDef)
+	relatedPrimaryIDFieldType := relatedModelDef.Fields[relatedPrimaryIDFieldName].Type
+	_, hasOverride := config.MorpheTypeOverridesConfig.GetModelFieldOverride(relatedModelDef.Name, relatedPrimaryIDFieldName, string(relatedPrimaryIDFieldType))
+	isOptional := hasAttribute(relationDef.Attributes, "optional")
+
+	idRules := getValidateRelatedIDFieldRules(relationDef.Type, string(relatedPrimaryIDFieldType), hasOverride, isOptional)
+	goIDField.Tags = append(goIDField.Tags, buildValidateFieldTags(idRules, isOptional)...)
+	goRelatedField.Tags = append(goRelatedField.Tags, buildValidateFieldTags(getValidateRelatedFieldRules(relationDef.Type), false)...)
+}
+
+// getModelPolyTypeFieldTags constructs the struct tags for the type field of a polymorphic For* relation.
+func getModelPolyTypeFieldTags(config cfg.MorpheModelsConfig, typeFieldName string, relationDef yaml.ModelRelation, fieldCasing cfg.Casing) []string {
+	tags := buildFieldTags(typeFieldName, nil, fieldCasing)
+	tags = append(tags, buildORMFieldTags(config.ORMTags, typeFieldName, getORMPolyField(relationDef))...)
+	if config.ValidateTags {
+		isOptional := hasAttribute(relationDef.Attributes, "optional")
+		tags = append(tags, buildValidateFieldTags(getValidatePolyTypeFieldRules(relationDef.For, isOptional), isOptional)...)
+	}
+	return tags
+}
+
+// getModelPolyIDFieldTags constructs the struct tags for the ID field of a polymorphic For* relation.
+func getModelPolyIDFieldTags(config cfg.MorpheModelsConfig, idFieldName string, relationDef yaml.ModelRelation, fieldCasing cfg.Casing) []string {
+	tags := buildFieldTags(idFieldName, nil, fieldCasing)
+	tags = append(tags, buildORMFieldTags(config.ORMTags, idFieldName, getORMPolyField(relationDef))...)
+	if config.ValidateTags {
+		isOptional := hasAttribute(relationDef.Attributes, "optional")
+		tags = append(tags, buildValidateFieldTags(getValidatePolyIDFieldRules(isOptional), isOptional)...)
+	}
+	return tags
+}
+
 func getRelatedGoFieldForMorpheModel(relationshipName, targetModelName string, relationDef yaml.ModelRelation, fieldCasing cfg.Casing) godef.StructField {
 	// Use relationship name for field naming (semantic)
 	fieldName := relationshipName
@@ -442,6 +566,16 @@ func getImportsForStructFields(structPackage godef.Package, allFields []godef.St
 	return allStructImports, nil
 }
 
+// addStructImport adds the import path to the sorted struct imports, unless it is already imported.
+func addStructImport(structImports []string, importPath string) []string {
+	if slices.Contains(structImports, importPath) {
+		return structImports
+	}
+	allStructImports := append(slices.Clone(structImports), importPath)
+	sort.Strings(allStructImports)
+	return allStructImports
+}
+
 func triggerCompileMorpheModelStart(modelHooks hook.CompileMorpheModel, config cfg.MorpheConfig, model yaml.Model) (cfg.MorpheConfig, yaml.Model, error) {
 	if modelHooks.OnCompileMorpheModelStart == nil {
 		return config, model, nil
@@ -1,7 +1,9 @@
 package compile_test
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/kalo-build/go/pkg/godef"
@@ -2339,3 +2341,2070 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_Related_HasMany_
 		ValueType: godef.GoTypeStruct{Name: "Project"},
 	}, structFields0[5].Type)
 }
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_CivilDate() {
+	config := suite.getCompileConfig()
+	config.MorpheSupportConfig = cfg.MorpheSupportConfig{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/support",
+			Name: "support",
+		},
+		CivilDate: true,
+	}
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"Birthday": {
+				Type:       yaml.ModelFieldTypeDate,
+				Attributes: []string{"optional"},
+			},
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"StartDate": {
+				Type: yaml.ModelFieldTypeDate,
+			},
+			"Time": {
+				Type: yaml.ModelFieldTypeTime,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	r := registry.NewRegistry()
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	goStruct0 := allGoStructs[0]
+	suite.Equal([]string{"github.com/kalo-build/project/domain/support", "time"}, goStruct0.Imports)
+
+	dateType := godef.GoTypeStruct{
+		PackagePath: "github.com/kalo-build/project/domain/support",
+		Name:        "Date",
+	}
+
+	structFields0 := goStruct0.Fields
+	suite.Len(structFields0, 4)
+
+	suite.Equal("Birthday", structFields0[0].Name)
+	suite.Equal(godef.GoTypePointer{ValueType: dateType}, structFields0[0].Type)
+
+	suite.Equal("StartDate", structFields0[2].Name)
+	suite.Equal(dateType, structFields0[2].Type)
+
+	suite.Equal("Time", structFields0[3].Name)
+	suite.Equal(godef.GoTypeTime, structFields0[3].Type)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_CivilDate_CustomType() {
+	config := suite.getCompileConfig()
+	config.MorpheSupportConfig = cfg.MorpheSupportConfig{
+		CivilDate: true,
+		DateType: godef.GoTypeStruct{
+			PackagePath: "cloud.google.com/go/civil",
+			Name:        "Date",
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"StartDate": {
+				Type: yaml.ModelFieldTypeDate,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	r := registry.NewRegistry()
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	goStruct0 := allGoStructs[0]
+	suite.Equal([]string{"cloud.google.com/go/civil"}, goStruct0.Imports)
+
+	structFields0 := goStruct0.Fields
+	suite.Len(structFields0, 2)
+	suite.Equal("StartDate", structFields0[1].Name)
+	suite.Equal(config.MorpheSupportConfig.DateType, structFields0[1].Type)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_CivilDate_NoSupportPackagePath() {
+	config := suite.getCompileConfig()
+	config.MorpheSupportConfig = cfg.MorpheSupportConfig{
+		Package: godef.Package{
+			Name: "support",
+		},
+		CivilDate: true,
+	}
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	r := registry.NewRegistry()
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.NotNil(allStructsErr)
+	suite.ErrorIs(allStructsErr, cfg.ErrNoPackagePath)
+	suite.Nil(allGoStructs)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_RedactSecrets() {
+	config := suite.getCompileConfig()
+	config.MorpheSupportConfig = cfg.MorpheSupportConfig{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/support",
+			Name: "support",
+		},
+		RedactSecrets: true,
+	}
+
+	model0 := yaml.Model{
+		Name: "Account",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Password": {
+				Type: yaml.ModelFieldTypeProtected,
+			},
+			"Token": {
+				Type:       yaml.ModelFieldTypeSealed,
+				Attributes: []string{"optional"},
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	r := registry.NewRegistry()
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	goStruct0 := allGoStructs[0]
+	suite.Equal([]string{"github.com/kalo-build/project/domain/support"}, goStruct0.Imports)
+
+	structFields0 := goStruct0.Fields
+	suite.Len(structFields0, 3)
+
+	suite.Equal("Password", structFields0[1].Name)
+	suite.Equal(godef.GoTypeStruct{
+		PackagePath: "github.com/kalo-build/project/domain/support",
+		Name:        "Protected",
+	}, structFields0[1].Type)
+
+	suite.Equal("Token", structFields0[2].Name)
+	suite.Equal(godef.GoTypePointer{
+		ValueType: godef.GoTypeStruct{
+			PackagePath: "github.com/kalo-build/project/domain/support",
+			Name:        "Sealed",
+		},
+	}, structFields0[2].Type)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_TypeOverrides() {
+	config := suite.getCompileConfig()
+	config.MorpheTypeOverridesConfig = cfg.MorpheTypeOverridesConfig{
+		FieldTypes: map[string]cfg.TypeOverride{
+			"UUID": {
+				PackagePath: "github.com/google/uuid",
+				Name:        "UUID",
+			},
+		},
+		ModelFields: map[string]cfg.TypeOverride{
+			"Invoice.Amount": {
+				PackagePath: "github.com/shopspring/decimal",
+				Name:        "Decimal",
+			},
+			"Invoice.Number": {
+				Name: "int64",
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Invoice",
+		Fields: map[string]yaml.ModelField{
+			"Amount": {
+				Type: yaml.ModelFieldTypeFloat,
+			},
+			"ID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+			"Number": {
+				Type: yaml.ModelFieldTypeInteger,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+			"number": {
+				Fields: []string{
+					"Number",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Customer": {
+				Type: "ForOne",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Customer",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Invoice": {
+				Type: "HasMany",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Invoice", model0)
+	r.SetModel("Customer", model1)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 3)
+
+	uuidType := godef.GoTypeStruct{
+		PackagePath: "github.com/google/uuid",
+		Name:        "UUID",
+	}
+	decimalType := godef.GoTypeStruct{
+		PackagePath: "github.com/shopspring/decimal",
+		Name:        "Decimal",
+	}
+	int64Type := godef.GoTypeStruct{
+		Name: "int64",
+	}
+
+	goStruct0 := allGoStructs[0]
+	suite.Equal([]string{"github.com/google/uuid", "github.com/shopspring/decimal"}, goStruct0.Imports)
+
+	structFields0 := goStruct0.Fields
+	suite.Len(structFields0, 5)
+
+	suite.Equal("Amount", structFields0[0].Name)
+	suite.Equal(decimalType, structFields0[0].Type)
+
+	suite.Equal("ID", structFields0[1].Name)
+	suite.Equal(uuidType, structFields0[1].Type)
+
+	suite.Equal("Number", structFields0[2].Name)
+	suite.Equal(int64Type, structFields0[2].Type)
+
+	suite.Equal("CustomerID", structFields0[3].Name)
+	suite.Equal(uuidType, structFields0[3].Type)
+
+	goStruct1 := allGoStructs[1]
+	suite.Equal("InvoiceIDNumber", goStruct1.Name)
+	suite.Len(goStruct1.Fields, 1)
+	suite.Equal(int64Type, goStruct1.Fields[0].Type)
+
+	goStruct2 := allGoStructs[2]
+	suite.Equal("InvoiceIDPrimary", goStruct2.Name)
+	suite.Equal([]string{"github.com/google/uuid"}, goStruct2.Imports)
+	suite.Len(goStruct2.Fields, 1)
+	suite.Equal(uuidType, goStruct2.Fields[0].Type)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_TypeOverrides_InvalidModelField() {
+	config := suite.getCompileConfig()
+	config.MorpheTypeOverridesConfig = cfg.MorpheTypeOverridesConfig{
+		ModelFields: map[string]cfg.TypeOverride{
+			"Amount": {
+				PackagePath: "github.com/shopspring/decimal",
+				Name:        "Decimal",
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Invoice",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	r := registry.NewRegistry()
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.ErrorContains(allStructsErr, "invalid model field 'Amount'")
+	suite.Nil(allGoStructs)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_TypedIDs() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.TypedIDs = true
+	config.MorpheTypeOverridesConfig = cfg.MorpheTypeOverridesConfig{
+		FieldTypes: map[string]cfg.TypeOverride{
+			"UUID": {
+				PackagePath: "github.com/google/uuid",
+				Name:        "UUID",
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+			"company": {
+				Fields: []string{
+					"rel:Company",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Company": {
+				Type: "ForOne",
+			},
+			"Note": {
+				Type: "HasMany",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Company",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Person": {
+				Type: "HasMany",
+			},
+		},
+	}
+	model2 := yaml.Model{
+		Name: "Note",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Person": {
+				Type: "ForOne",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+	r.SetModel("Company", model1)
+	r.SetModel("Note", model2)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 3)
+
+	personIDType := godef.GoTypeDerived{
+		Name: "PersonID",
+		BaseType: godef.GoTypeStruct{
+			PackagePath: "github.com/google/uuid",
+			Name:        "UUID",
+		},
+	}
+	companyIDType := godef.GoTypeDerived{
+		Name:     "CompanyID",
+		BaseType: godef.GoTypeUint,
+	}
+	noteIDType := godef.GoTypeDerived{
+		Name:     "NoteID",
+		BaseType: godef.GoTypeUint,
+	}
+
+	goStruct0 := allGoStructs[0]
+	suite.Equal("Person", goStruct0.Name)
+	suite.Equal([]string{"github.com/google/uuid"}, goStruct0.Imports)
+
+	structFields0 := goStruct0.Fields
+	suite.Len(structFields0, 5)
+
+	suite.Equal("ID", structFields0[0].Name)
+	suite.Equal(personIDType, structFields0[0].Type)
+
+	suite.Equal("CompanyID", structFields0[1].Name)
+	suite.Equal(companyIDType, structFields0[1].Type)
+
+	suite.Equal("NoteIDs", structFields0[3].Name)
+	suite.Equal(godef.GoTypeArray{
+		IsSlice:   true,
+		ValueType: noteIDType,
+	}, structFields0[3].Type)
+
+	goStruct1 := allGoStructs[1]
+	suite.Equal("PersonIDCompany", goStruct1.Name)
+	suite.Empty(goStruct1.Imports)
+	suite.Len(goStruct1.Fields, 1)
+	suite.Equal(companyIDType, goStruct1.Fields[0].Type)
+
+	goStruct2 := allGoStructs[2]
+	suite.Equal("PersonIDPrimary", goStruct2.Name)
+	suite.Empty(goStruct2.Imports)
+	suite.Len(goStruct2.Fields, 1)
+	suite.Equal(personIDType, goStruct2.Fields[0].Type)
+}
+
+func (suite *CompileModelsTestSuite) getTypedPolyRegistry() (*registry.Registry, yaml.Model, yaml.Model) {
+	postModel := yaml.Model{
+		Name: "Post",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+	}
+
+	articleModel := yaml.Model{
+		Name: "Article",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+	}
+
+	commentModel := yaml.Model{
+		Name: "Comment",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Commentable": {
+				Type: "ForOnePoly",
+				For:  []string{"Post", "Article"},
+			},
+		},
+	}
+
+	r := registry.NewRegistry()
+	r.SetModel("Post", postModel)
+	r.SetModel("Article", articleModel)
+	r.SetModel("Comment", commentModel)
+	return r, commentModel, postModel
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_TypedPolyRelations() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.TypedPolyRelations = true
+
+	r, commentModel, _ := suite.getTypedPolyRegistry()
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, commentModel)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	goStruct0 := allGoStructs[0]
+	suite.Equal("Comment", goStruct0.Name)
+	suite.Empty(goStruct0.Imports)
+
+	structFields0 := goStruct0.Fields
+	suite.Len(structFields0, 4)
+
+	suite.Equal("CommentableType", structFields0[1].Name)
+	suite.Equal(godef.GoTypeDerived{
+		Name:     "CommentableType",
+		BaseType: godef.GoTypeString,
+	}, structFields0[1].Type)
+
+	suite.Equal("CommentableID", structFields0[2].Name)
+	suite.Equal(godef.GoTypeString, structFields0[2].Type)
+
+	suite.Equal("Commentable", structFields0[3].Name)
+	suite.Equal(godef.GoTypeInterface{
+		Name: "Commentable",
+	}, structFields0[3].Type)
+	suite.Equal([]string{`json:"-"`}, structFields0[3].Tags)
+
+	structMethods0 := goStruct0.Methods
+	suite.Len(structMethods0, 4)
+
+	suite.Equal("GetIDPrimary", structMethods0[0].Name)
+
+	setter := structMethods0[1]
+	suite.Equal("SetCommentable", setter.Name)
+	suite.Equal(godef.GoTypePointer{
+		ValueType: godef.GoTypeStruct{
+			PackagePath: "github.com/kalo-build/project/domain/models",
+			Name:        "Comment",
+		},
+	}, setter.ReceiverType)
+	suite.Equal(map[string]godef.GoType{
+		"commentable": godef.GoTypeInterface{Name: "Commentable"},
+	}, setter.Parameters)
+	suite.Equal([]string{
+		"\tm.CommentableType = commentable.commentableType()",
+		"\tm.CommentableID = commentable.commentableID()",
+		"\tm.Commentable = commentable",
+	}, setter.BodyLines)
+
+	articleGetter := structMethods0[2]
+	suite.Equal("CommentableAsArticle", articleGetter.Name)
+	suite.Equal([]godef.GoType{
+		godef.GoTypeStruct{
+			PackagePath: "github.com/kalo-build/project/domain/models",
+			Name:        "Article",
+		},
+		godef.GoTypeBool,
+	}, articleGetter.ReturnTypes)
+	suite.Equal([]string{
+		"\tswitch article := m.Commentable.(type) {",
+		"\tcase Article:",
+		"\t\treturn article, true",
+		"\tcase *Article:",
+		"\t\tif article != nil {",
+		"\t\t\treturn *article, true",
+		"\t\t}",
+		"\t}",
+		"\treturn Article{}, false",
+	}, articleGetter.BodyLines)
+
+	suite.Equal("CommentableAsPost", structMethods0[3].Name)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_TypedPolyRelations_Target() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.TypedPolyRelations = true
+
+	r, _, postModel := suite.getTypedPolyRegistry()
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, postModel)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	goStruct0 := allGoStructs[0]
+	suite.Equal("Post", goStruct0.Name)
+	suite.Equal([]string{"fmt"}, goStruct0.Imports)
+
+	structMethods0 := goStruct0.Methods
+	suite.Len(structMethods0, 3)
+
+	typeMethod := structMethods0[1]
+	suite.Equal("commentableType", typeMethod.Name)
+	suite.Equal([]godef.GoType{
+		godef.GoTypeDerived{
+			Name:     "CommentableType",
+			BaseType: godef.GoTypeString,
+		},
+	}, typeMethod.ReturnTypes)
+	suite.Equal([]string{"\treturn CommentableTypePost"}, typeMethod.BodyLines)
+
+	idMethod := structMethods0[2]
+	suite.Equal("commentableID", idMethod.Name)
+	suite.Equal([]godef.GoType{godef.GoTypeString}, idMethod.ReturnTypes)
+	suite.Equal([]string{"\treturn fmt.Sprint(m.ID)"}, idMethod.BodyLines)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_TypedPolyRelations_TargetsConflict() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.TypedPolyRelations = true
+
+	r, _, postModel := suite.getTypedPolyRegistry()
+	r.SetModel("Reaction", yaml.Model{
+		Name: "Reaction",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Commentable": {
+				Type: "ForOnePoly",
+				For:  []string{"Post"},
+			},
+		},
+	})
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, postModel)
+
+	suite.Nil(allGoStructs)
+	suite.ErrorContains(allStructsErr, "polymorphic relation 'Commentable' of models 'Comment' and 'Reaction' must link to the same models")
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_ORMTags() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ORMTags = cfg.ORMTagsConfig{
+		DB:   true,
+		Gorm: true,
+		Bun:  true,
+	}
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Email": {
+				Type: yaml.ModelFieldTypeString,
+				Attributes: []string{
+					"immutable",
+				},
+			},
+			"Nickname": {
+				Type: yaml.ModelFieldTypeString,
+				Attributes: []string{
+					"optional",
+				},
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Company": {
+				Type: "ForOne",
+			},
+			"Note": {
+				Type: "HasMany",
+			},
+			"Tag": {
+				Type: "ForMany",
+			},
+			"Comment": {
+				Type:    "HasManyPoly",
+				Through: "Commentable",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Company",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	model2 := yaml.Model{
+		Name: "Note",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Author": {
+				Type:    "ForOne",
+				Aliased: "Person",
+			},
+		},
+	}
+	model3 := yaml.Model{
+		Name: "Tag",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	model4 := yaml.Model{
+		Name: "Comment",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Commentable": {
+				Type: "ForOnePoly",
+				For: []string{
+					"Person",
+				},
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+	r.SetModel("Company", model1)
+	r.SetModel("Note", model2)
+	r.SetModel("Tag", model3)
+	r.SetModel("Comment", model4)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	structFields0 := allGoStructs[0].Fields
+	suite.Len(structFields0, 11)
+
+	suite.Equal("Email", structFields0[0].Name)
+	suite.Equal([]string{
+		`morphe:"immutable"`,
+		`db:"email"`,
+		`gorm:"column:email;not null;<-:create"`,
+		`bun:"email,notnull"`,
+	}, structFields0[0].Tags)
+
+	suite.Equal("ID", structFields0[1].Name)
+	suite.Equal([]string{
+		`db:"id"`,
+		`gorm:"column:id;primaryKey;autoIncrement"`,
+		`bun:"id,pk,autoincrement"`,
+	}, structFields0[1].Tags)
+
+	suite.Equal("Nickname", structFields0[2].Name)
+	suite.Equal([]string{
+		`morphe:"optional"`,
+		`db:"nickname"`,
+		`gorm:"column:nickname"`,
+		`bun:"nickname"`,
+	}, structFields0[2].Tags)
+
+	suite.Equal("CommentIDs", structFields0[3].Name)
+	suite.Equal([]string{`db:"-"`, `gorm:"-"`, `bun:"-"`}, structFields0[3].Tags)
+
+	suite.Equal("Comments", structFields0[4].Name)
+	suite.Equal([]string{
+		`db:"-"`,
+		`gorm:"polymorphic:Commentable;polymorphicValue:Person"`,
+		`bun:"rel:has-many,join:id=commentable_id,join:type=commentable_type,polymorphic"`,
+	}, structFields0[4].Tags)
+
+	suite.Equal("CompanyID", structFields0[5].Name)
+	suite.Equal([]string{
+		`db:"company_id"`,
+		`gorm:"column:company_id;not null"`,
+		`bun:"company_id,notnull"`,
+	}, structFields0[5].Tags)
+
+	suite.Equal("Company", structFields0[6].Name)
+	suite.Equal([]string{
+		`db:"-"`,
+		`gorm:"foreignKey:CompanyID;references:ID"`,
+		`bun:"rel:belongs-to,join:company_id=id"`,
+	}, structFields0[6].Tags)
+
+	suite.Equal("Notes", structFields0[8].Name)
+	suite.Equal([]string{
+		`db:"-"`,
+		`gorm:"foreignKey:AuthorID;references:ID"`,
+		`bun:"rel:has-many,join:id=author_id"`,
+	}, structFields0[8].Tags)
+
+	suite.Equal("Tags", structFields0[10].Name)
+	suite.Equal([]string{
+		`db:"-"`,
+		`gorm:"many2many:person_tags"`,
+		`bun:"m2m:person_tags,join:Person=Tag"`,
+	}, structFields0[10].Tags)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_ORMTags_ColumnCasing() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.FieldCasing = cfg.CasingCamel
+	config.MorpheModelsConfig.ORMTags = cfg.ORMTagsConfig{
+		DB:           true,
+		ColumnCasing: cfg.CasingPascal,
+	}
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"FirstName": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	structFields0 := allGoStructs[0].Fields
+	suite.Len(structFields0, 2)
+	suite.Equal([]string{`json:"firstName"`, `db:"FirstName"`}, structFields0[0].Tags)
+	suite.Equal([]string{`json:"id"`, `db:"ID"`}, structFields0[1].Tags)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_ORMTags_InvalidColumnCasing() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ORMTags = cfg.ORMTagsConfig{
+		DB:           true,
+		ColumnCasing: "kebab",
+	}
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allGoStructs)
+	suite.ErrorContains(allStructsErr, "columnCasing")
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_ValidateTags() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ValidateTags = true
+
+	model0 := yaml.Model{
+		Name: "Comment",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+			"Pinned": {
+				Type: yaml.ModelFieldTypeBoolean,
+			},
+			"Text": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Author": {
+				Type:    "ForOne",
+				Aliased: "Person",
+			},
+			"Editor": {
+				Type:    "ForOne",
+				Aliased: "Person",
+				Attributes: []string{
+					"optional",
+				},
+			},
+			"Commentable": {
+				Type: "ForOnePoly",
+				For: []string{
+					"Post",
+					"Person",
+				},
+			},
+			"Reply": {
+				Type:    "HasMany",
+				Aliased: "Comment",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	model2 := yaml.Model{
+		Name: "Post",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Comment", model0)
+	r.SetModel("Person", model1)
+	r.SetModel("Post", model2)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	structFields0 := allGoStructs[0].Fields
+	suite.Len(structFields0, 11)
+
+	suite.Equal("ID", structFields0[0].Name)
+	suite.Equal([]string{`validate:"required,uuid"`}, structFields0[0].Tags)
+
+	suite.Equal("Pinned", structFields0[1].Name)
+	suite.Nil(structFields0[1].Tags)
+
+	suite.Equal("Text", structFields0[2].Name)
+	suite.Equal([]string{`validate:"required"`}, structFields0[2].Tags)
+
+	suite.Equal("AuthorID", structFields0[3].Name)
+	suite.Equal([]string{`validate:"required,uuid"`}, structFields0[3].Tags)
+
+	suite.Equal("Author", structFields0[4].Name)
+	suite.Nil(structFields0[4].Tags)
+
+	suite.Equal("CommentableType", structFields0[5].Name)
+	suite.Equal([]string{`validate:"required,oneof=Person Post"`}, structFields0[5].Tags)
+
+	suite.Equal("CommentableID", structFields0[6].Name)
+	suite.Equal([]string{`validate:"required"`}, structFields0[6].Tags)
+
+	suite.Equal("EditorID", structFields0[7].Name)
+	suite.Equal([]string{`validate:"omitempty,uuid"`}, structFields0[7].Tags)
+
+	suite.Equal("ReplyIDs", structFields0[9].Name)
+	suite.Nil(structFields0[9].Tags)
+
+	suite.Equal("Replies", structFields0[10].Name)
+	suite.Equal([]string{`validate:"dive"`}, structFields0[10].Tags)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_FieldOrderDeclaration() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.FieldOrder = cfg.FieldOrderDeclaration
+	config.DeclarationOrders = cfg.DeclarationOrders{
+		Models: map[string]cfg.DeclarationOrder{
+			"Person": {
+				Identifiers: []string{"primary", "email"},
+				Fields:      []string{"Name", "Email", "ID"},
+				Related:     []string{"Team", "Company"},
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"Age": {
+				Type: yaml.ModelFieldTypeInteger,
+			},
+			"Email": {
+				Type: yaml.ModelFieldTypeString,
+			},
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Name": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+			"email": {
+				Fields: []string{
+					"Email",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Company": {
+				Type: "ForOne",
+			},
+			"Team": {
+				Type: "ForOne",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Company",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	model2 := yaml.Model{
+		Name:        "Team",
+		Fields:      model1.Fields,
+		Identifiers: model1.Identifiers,
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+	r.SetModel("Company", model1)
+	r.SetModel("Team", model2)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 3)
+
+	allFieldNames := []string{}
+	for _, field := range allGoStructs[0].Fields {
+		allFieldNames = append(allFieldNames, field.Name)
+	}
+	// Identifier fields first, then the declared fields, then undeclared fields sorted (ie. added by a hook), then relations
+	suite.Equal([]string{"ID", "Email", "Name", "Age", "TeamID", "Team", "CompanyID", "Company"}, allFieldNames)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_InvalidFieldOrder() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.FieldOrder = "yaml"
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allGoStructs)
+	suite.ErrorContains(allStructsErr, "invalid fieldOrder value")
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_JSONTags() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.FieldCasing = cfg.CasingCamel
+	config.MorpheModelsConfig.JSONTags = cfg.JSONTagsConfig{
+		OmitOptional: cfg.JSONOmitEmpty,
+		Relations:    cfg.JSONRelationsExclude,
+		Fields: map[string]cfg.JSONFieldOverride{
+			"Comment.ID": {
+				String: true,
+			},
+			"Comment.Text": {
+				Name: "body",
+			},
+			"Comment.Secret": {
+				Name: "-",
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Comment",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Secret": {
+				Type: yaml.ModelFieldTypeString,
+			},
+			"Text": {
+				Type: yaml.ModelFieldTypeString,
+				Attributes: []string{
+					"optional",
+				},
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Author": {
+				Type:    "ForOne",
+				Aliased: "Person",
+			},
+			"Reply": {
+				Type:    "HasMany",
+				Aliased: "Comment",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Comment", model0)
+	r.SetModel("Person", model1)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	structFields0 := allGoStructs[0].Fields
+	suite.Len(structFields0, 7)
+
+	suite.Equal("ID", structFields0[0].Name)
+	suite.Equal([]string{`json:"id,string"`}, structFields0[0].Tags)
+
+	suite.Equal("Secret", structFields0[1].Name)
+	suite.Equal([]string{`json:"-"`}, structFields0[1].Tags)
+
+	suite.Equal("Text", structFields0[2].Name)
+	suite.Equal([]string{`morphe:"optional"`, `json:"body,omitempty"`}, structFields0[2].Tags)
+
+	suite.Equal("AuthorID", structFields0[3].Name)
+	suite.Equal([]string{`json:"authorID"`}, structFields0[3].Tags)
+
+	suite.Equal("Author", structFields0[4].Name)
+	suite.Equal([]string{`json:"-"`}, structFields0[4].Tags)
+
+	suite.Equal("ReplyIDs", structFields0[5].Name)
+	suite.Equal([]string{`json:"replyIDs"`}, structFields0[5].Tags)
+
+	suite.Equal("Replies", structFields0[6].Name)
+	suite.Equal([]string{`json:"-"`}, structFields0[6].Tags)
+
+	// Identifier structs inherit the JSON tags of the model fields
+	structFields1 := allGoStructs[1].Fields
+	suite.Len(structFields1, 1)
+	suite.Equal("ID", structFields1[0].Name)
+	suite.Equal([]string{`json:"id,string"`}, structFields1[0].Tags)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_JSONTags_NoFieldCasing() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.JSONTags = cfg.JSONTagsConfig{
+		OmitOptional: cfg.JSONOmitZero,
+		Relations:    cfg.JSONRelationsOmitEmpty,
+	}
+
+	model0 := yaml.Model{
+		Name: "Comment",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Text": {
+				Type: yaml.ModelFieldTypeString,
+				Attributes: []string{
+					"optional",
+				},
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Reply": {
+				Type:    "HasMany",
+				Aliased: "Comment",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Comment", model0)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	structFields0 := allGoStructs[0].Fields
+	suite.Len(structFields0, 4)
+
+	suite.Equal("ID", structFields0[0].Name)
+	suite.Nil(structFields0[0].Tags)
+
+	suite.Equal("Text", structFields0[1].Name)
+	suite.Equal([]string{`morphe:"optional"`, `json:"Text,omitzero"`}, structFields0[1].Tags)
+
+	suite.Equal("ReplyIDs", structFields0[2].Name)
+	suite.Nil(structFields0[2].Tags)
+
+	suite.Equal("Replies", structFields0[3].Name)
+	suite.Equal([]string{`json:"Replies,omitempty"`}, structFields0[3].Tags)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_JSONTags_InvalidField() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.JSONTags = cfg.JSONTagsConfig{
+		Fields: map[string]cfg.JSONFieldOverride{
+			"ID": {
+				Name: "id",
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allGoStructs)
+	suite.ErrorContains(allStructsErr, "invalid json field 'ID'")
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_ValidateMethods() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ValidateMethods = true
+
+	model0 := yaml.Model{
+		Name: "Comment",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Language": {
+				Type: "Language",
+				Attributes: []string{
+					"optional",
+				},
+			},
+			"Status": {
+				Type: "Status",
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Author": {
+				Type:    "ForOne",
+				Aliased: "Person",
+			},
+			"Commentable": {
+				Type: "ForOnePoly",
+				For: []string{
+					"Post",
+					"Person",
+				},
+				Attributes: []string{
+					"optional",
+				},
+			},
+			"Editor": {
+				Type:    "ForOne",
+				Aliased: "Person",
+				Attributes: []string{
+					"optional",
+				},
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	enum0 := yaml.Enum{
+		Name: "Language",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"EN": "en",
+		},
+	}
+	enum1 := yaml.Enum{
+		Name: "Status",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"Draft":     "draft",
+			"Published": "published",
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Comment", model0)
+	r.SetModel("Person", model1)
+	r.SetEnum("Language", enum0)
+	r.SetEnum("Status", enum1)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	structMethods0 := allGoStructs[0].Methods
+	suite.Len(structMethods0, 2)
+	suite.Equal("GetIDPrimary", structMethods0[0].Name)
+
+	validateMethod := structMethods0[1]
+	suite.Equal("Validate", validateMethod.Name)
+	suite.Equal([]godef.GoType{godef.GoTypeError}, validateMethod.ReturnTypes)
+	suite.Equal([]string{
+		"\tvar errs ValidationErrors",
+		"\tif m.Language != nil && !m.Language.IsValid() {",
+		"\t\terrs = append(errs, ValidationError{Path: \"Language\", Message: \"invalid value\"})",
+		"\t}",
+		"\tif !m.Status.IsValid() {",
+		"\t\terrs = append(errs, ValidationError{Path: \"Status\", Message: \"invalid value\"})",
+		"\t}",
+		"\tif m.Author == nil {",
+		"\t\terrs = append(errs, ValidationError{Path: \"Author\", Message: \"is required\"})",
+		"\t}",
+		"\tif m.CommentableType != \"\" && m.CommentableType != \"Person\" && m.CommentableType != \"Post\" {",
+		"\t\terrs = append(errs, ValidationError{Path: \"CommentableType\", Message: \"must be one of: Person, Post\"})",
+		"\t}",
+		"\treturn errs.orNil()",
+	}, validateMethod.BodyLines)
+}
+
+func (suite *CompileModelsTestSuite) getConstructorsRegistry() (*registry.Registry, yaml.Model) {
+	model0 := yaml.Model{
+		Name: "Comment",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Body": {
+				Type: yaml.ModelFieldTypeString,
+			},
+			"Language": {
+				Type: "Language",
+			},
+			"Title": {
+				Type: yaml.ModelFieldTypeString,
+				Attributes: []string{
+					"optional",
+				},
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Author": {
+				Type:    "ForOne",
+				Aliased: "Person",
+			},
+			"Editor": {
+				Type:    "ForOne",
+				Aliased: "Person",
+				Attributes: []string{
+					"optional",
+				},
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	enum0 := yaml.Enum{
+		Name: "Language",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"EN": "en",
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Comment", model0)
+	r.SetModel("Person", model1)
+	r.SetEnum("Language", enum0)
+	return r, model0
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_Constructors() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.Constructors = true
+	r, model0 := suite.getConstructorsRegistry()
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	structMethods0 := allGoStructs[0].Methods
+	suite.Len(structMethods0, 4)
+	suite.Equal("GetIDPrimary", structMethods0[0].Name)
+
+	receiverType := godef.GoTypeStruct{
+		PackagePath: "github.com/kalo-build/project/domain/models",
+		Name:        "Comment",
+	}
+	suite.Equal(godef.StructMethod{
+		ReceiverName: "m",
+		ReceiverType: receiverType,
+		Name:         "WithID",
+		Parameters: map[string]godef.GoType{
+			"id": godef.GoTypeUint,
+		},
+		ReturnTypes: []godef.GoType{receiverType},
+		BodyLines: []string{
+			"\tm.ID = id",
+			"\treturn m",
+		},
+	}, structMethods0[1])
+	suite.Equal(godef.StructMethod{
+		ReceiverName: "m",
+		ReceiverType: receiverType,
+		Name:         "WithTitle",
+		Parameters: map[string]godef.GoType{
+			"title": godef.GoTypeString,
+		},
+		ReturnTypes: []godef.GoType{receiverType},
+		BodyLines: []string{
+			"\tm.Title = &title",
+			"\treturn m",
+		},
+	}, structMethods0[2])
+	suite.Equal("WithEditorID", structMethods0[3].Name)
+	suite.Equal([]string{
+		"\tm.EditorID = &editorID",
+		"\treturn m",
+	}, structMethods0[3].BodyLines)
+}
+
+func (suite *CompileModelsTestSuite) TestAllMorpheModelConstructors() {
+	config := suite.getMorpheConfig()
+	config.MorpheModelsConfig.Constructors = true
+	r, _ := suite.getConstructorsRegistry()
+
+	allConstructorDefs, constructorsErr := compile.AllMorpheModelConstructors(compile.MorpheCompileConfig{MorpheConfig: config}, r)
+
+	suite.Nil(constructorsErr)
+	suite.Len(allConstructorDefs, 2)
+
+	commentConstructor := allConstructorDefs["NewComment"]
+	suite.Contains(commentConstructor, "import (\n\t\"github.com/kalo-build/project/domain/enums\"\n)\n")
+	suite.Contains(commentConstructor, "func NewComment(body string, language enums.Language, authorID uint) Comment {\n")
+	suite.Contains(commentConstructor, "\treturn Comment{\n\t\tBody: body,\n\t\tLanguage: language,\n\t\tAuthorID: authorID,\n\t}\n")
+
+	personConstructor := allConstructorDefs["NewPerson"]
+	suite.Contains(personConstructor, "func NewPerson() Person {\n\treturn Person{}\n}\n")
+
+	config.MorpheModelsConfig.Constructors = false
+
+	allConstructorDefs, constructorsErr = compile.AllMorpheModelConstructors(compile.MorpheCompileConfig{MorpheConfig: config}, r)
+
+	suite.Nil(constructorsErr)
+	suite.Empty(allConstructorDefs)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_CloneMethods() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.CloneMethods = true
+
+	model0 := yaml.Model{
+		Name: "Company",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Name": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Person": {
+				Type: "HasMany",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Nickname": {
+				Type: yaml.ModelFieldTypeString,
+				Attributes: []string{
+					"optional",
+				},
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Company": {
+				Type: "ForOne",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Company", model0)
+	r.SetModel("Person", model1)
+
+	allGoStructs0, allStructsErr0 := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr0)
+	suite.Len(allGoStructs0, 2)
+
+	structMethods0 := allGoStructs0[0].Methods
+	suite.Len(structMethods0, 3)
+	suite.Equal("GetIDPrimary", structMethods0[0].Name)
+	suite.Equal("Clone", structMethods0[1].Name)
+	suite.Equal("cloneWith", structMethods0[2].Name)
+	suite.Equal([]string{
+		"\tclone := m",
+		"\tclone.PersonIDs = cloneSlice(m.PersonIDs)",
+		"\tclone.People = cloneStructs(m.People, clones, Person.cloneWith)",
+		"\treturn clone",
+	}, structMethods0[2].BodyLines)
+
+	allGoStructs1, allStructsErr1 := compile.MorpheModelToGoStructs(config, r, model1)
+
+	suite.Nil(allStructsErr1)
+	suite.Len(allGoStructs1, 2)
+
+	structMethods1 := allGoStructs1[0].Methods
+	suite.Len(structMethods1, 3)
+	suite.Equal([]string{
+		"\tclone := m",
+		"\tclone.Nickname = cloneValue(m.Nickname)",
+		"\tclone.Company = clonePointer(m.Company, clones, Company.cloneWith)",
+		"\treturn clone",
+	}, structMethods1[2].BodyLines)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_DiffMethods() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.DiffMethods = true
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Nickname": {
+				Type: yaml.ModelFieldTypeString,
+				Attributes: []string{
+					"optional",
+				},
+			},
+			"SeenAt": {
+				Type: yaml.ModelFieldTypeTime,
+				Attributes: []string{
+					"optional",
+				},
+			},
+			"UpdatedAt": {
+				Type: yaml.ModelFieldTypeTime,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Company": {
+				Type: "ForOne",
+			},
+			"Note": {
+				Type: "HasMany",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Company",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	model2 := yaml.Model{
+		Name: "Note",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+	r.SetModel("Company", model1)
+	r.SetModel("Note", model2)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	structMethods0 := allGoStructs[0].Methods
+	suite.Len(structMethods0, 3)
+	suite.Equal("GetIDPrimary", structMethods0[0].Name)
+
+	otherType := godef.GoTypeStruct{
+		Name: "Person",
+	}
+	equalMethod := structMethods0[1]
+	suite.Equal("Equal", equalMethod.Name)
+	suite.Equal(map[string]godef.GoType{"other": otherType}, equalMethod.Parameters)
+	suite.Equal([]godef.GoType{godef.GoTypeBool}, equalMethod.ReturnTypes)
+	suite.Equal([]string{
+		"\treturn m.ID == other.ID &&\n" +
+			"\t\tequalPointer(m.Nickname, other.Nickname) &&\n" +
+			"\t\tequalPointerFunc(m.SeenAt, other.SeenAt, time.Time.Equal) &&\n" +
+			"\t\tm.UpdatedAt.Equal(other.UpdatedAt) &&\n" +
+			"\t\tm.CompanyID == other.CompanyID &&\n" +
+			"\t\tequalSlice(m.NoteIDs, other.NoteIDs)",
+	}, equalMethod.BodyLines)
+
+	diffMethod := structMethods0[2]
+	suite.Equal("Diff", diffMethod.Name)
+	suite.Equal([]godef.GoType{
+		godef.GoTypeArray{
+			IsSlice:   true,
+			ValueType: godef.GoTypeStruct{Name: "FieldChange"},
+		},
+	}, diffMethod.ReturnTypes)
+	suite.Equal([]string{
+		"\tchanges := []FieldChange{}",
+		"\tif m.ID != other.ID {",
+		"\t\tchanges = append(changes, FieldChange{Field: \"ID\", Old: m.ID, New: other.ID})",
+		"\t}",
+		"\tif !equalPointer(m.Nickname, other.Nickname) {",
+		"\t\tchanges = append(changes, FieldChange{Field: \"Nickname\", Old: pointerValue(m.Nickname), New: pointerValue(other.Nickname)})",
+		"\t}",
+		"\tif !equalPointerFunc(m.SeenAt, other.SeenAt, time.Time.Equal) {",
+		"\t\tchanges = append(changes, FieldChange{Field: \"SeenAt\", Old: pointerValue(m.SeenAt), New: pointerValue(other.SeenAt)})",
+		"\t}",
+		"\tif !m.UpdatedAt.Equal(other.UpdatedAt) {",
+		"\t\tchanges = append(changes, FieldChange{Field: \"UpdatedAt\", Old: m.UpdatedAt, New: other.UpdatedAt})",
+		"\t}",
+		"\tif m.CompanyID != other.CompanyID {",
+		"\t\tchanges = append(changes, FieldChange{Field: \"CompanyID\", Old: m.CompanyID, New: other.CompanyID})",
+		"\t}",
+		"\tif !equalSlice(m.NoteIDs, other.NoteIDs) {",
+		"\t\tchanges = append(changes, FieldChange{Field: \"NoteIDs\", Old: m.NoteIDs, New: other.NoteIDs})",
+		"\t}",
+		"\treturn changes",
+	}, diffMethod.BodyLines)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToGoStructs_DiffMethods_TypeOverrides() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.DiffMethods = true
+	config.MorpheTypeOverridesConfig.FieldTypes = map[string]cfg.TypeOverride{
+		"UUID": {PackagePath: "github.com/google/uuid", Name: "UUID"},
+	}
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Note": {
+				Type: "HasMany",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Note",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+	r.SetModel("Note", model1)
+
+	allGoStructs, allStructsErr := compile.MorpheModelToGoStructs(config, r, model0)
+
+	suite.Nil(allStructsErr)
+	suite.Len(allGoStructs, 2)
+
+	structMethods0 := allGoStructs[0].Methods
+	suite.Len(structMethods0, 3)
+
+	equalMethod := structMethods0[1]
+	suite.Equal("Equal", equalMethod.Name)
+	suite.Equal([]string{
+		"\treturn equalValue(m.ID, other.ID) &&\n" +
+			"\t\tequalSliceFunc(m.NoteIDs, other.NoteIDs, equalValue[uuid.UUID])",
+	}, equalMethod.BodyLines)
+}
+
+func (suite *CompileModelsTestSuite) TestAllMorpheModelsToGoStructs_AggregateErrors() {
+	config := suite.getCompileConfig()
+	config.AggregateErrors = true
+
+	getModel := func(modelName string, relationName string) yaml.Model {
+		return yaml.Model{
+			Name: modelName,
+			Fields: map[string]yaml.ModelField{
+				"ID": {
+					Type: yaml.ModelFieldTypeAutoIncrement,
+				},
+			},
+			Identifiers: map[string]yaml.ModelIdentifier{
+				"primary": {
+					Fields: []string{
+						"ID",
+					},
+				},
+			},
+			Related: map[string]yaml.ModelRelation{
+				relationName: {
+					Type: "ForOne",
+				},
+			},
+		}
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", getModel("Person", "Team"))
+	r.SetModel("Company", getModel("Company", "Office"))
+	r.SetModel("Basic", yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+	})
+
+	allGoStructs, allStructsErr := compile.AllMorpheModelsToGoStructs(config, r)
+
+	suite.Nil(allGoStructs)
+	var compileErrs compile.CompileErrors
+	suite.ErrorAs(allStructsErr, &compileErrs)
+	suite.Len(compileErrs, 2)
+
+	compileErr0 := compileErrs[0]
+	suite.Equal(compile.CompileErrorKindModel, compileErr0.Kind)
+	suite.Equal("Company", compileErr0.Definition)
+	suite.Equal("Office", compileErr0.Field)
+	suite.Contains(compileErr0.Message, "failed to get model 'Office' for relation 'Office'")
+
+	compileErr1 := compileErrs[1]
+	suite.Equal(compile.CompileErrorKindModel, compileErr1.Kind)
+	suite.Equal("Person", compileErr1.Definition)
+	suite.Equal("Team", compileErr1.Field)
+
+	suite.Equal(
+		"2 compile errors:\n"+
+			"  "+compileErr0.Error()+"\n"+
+			"  "+compileErr1.Error(),
+		allStructsErr.Error(),
+	)
+	suite.True(strings.HasPrefix(compileErr0.Error(), "model 'Company' field 'Office': failed to get model 'Office'"))
+}
+
+func (suite *CompileModelsTestSuite) TestAllMorpheModelsToGoStructs_FailFast() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Team": {
+				Type: "ForOne",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+
+	allGoStructs, allStructsErr := compile.AllMorpheModelsToGoStructs(config, r)
+
+	suite.Nil(allGoStructs)
+	var compileErrs compile.CompileErrors
+	suite.False(errors.As(allStructsErr, &compileErrs))
+	var compileErr *compile.CompileError
+	suite.ErrorAs(allStructsErr, &compileErr)
+	suite.Equal("Person", compileErr.Definition)
+	suite.Equal("Team", compileErr.Field)
+	suite.True(compileErr.Position.IsEmpty())
+	suite.ErrorContains(allStructsErr, "model 'Person' field 'Team': failed to get model 'Team' for relation 'Team'")
+}
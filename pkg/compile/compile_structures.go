@@ -7,18 +7,26 @@ import (
 	"github.com/kalo-build/morphe-go/pkg/yaml"
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/hook"
-	"github.com/kalo-build/plugin-morphe-go-struct/pkg/typemap"
 )
 
 func AllMorpheStructuresToGoStructs(config MorpheCompileConfig, r *registry.Registry) (map[string]*godef.Struct, error) {
 	allStructureStructDefs := map[string]*godef.Struct{}
-	for structureName, structure := range r.GetAllStructures() {
+	allErrs := CompileErrors{}
+	allStructures := r.GetAllStructures()
+	for _, structureName := range core.MapKeysSorted(allStructures) {
+		structure := allStructures[structureName]
 		structureStruct, structureErr := MorpheStructureToGoStruct(config, r, structure)
 		if structureErr != nil {
-			return nil, structureErr
+			if addErr := addCompileError(config, &allErrs, CompileErrorKindStructure, structureName, structureErr); addErr != nil {
+				return nil, addErr
+			}
+			continue
 		}
 		allStructureStructDefs[structureName] = structureStruct
 	}
+	if compileErrs := allErrs.Err(); compileErrs != nil {
+		return nil, compileErrs
+	}
 	return allStructureStructDefs, nil
 }
 
@@ -46,6 +54,14 @@ func morpheStructureToGoStruct(config MorpheCompileConfig, r *registry.Registry,
 	if validateConfigErr != nil {
 		return nil, validateConfigErr
 	}
+	validateSupportErr := config.MorpheSupportConfig.Validate()
+	if validateSupportErr != nil {
+		return nil, validateSupportErr
+	}
+	validateOverridesErr := config.MorpheTypeOverridesConfig.Validate()
+	if validateOverridesErr != nil {
+		return nil, validateOverridesErr
+	}
 	validateMorpheErr := structure.Validate(r.GetAllEnums(), r.GetAllStructures())
 	if validateMorpheErr != nil {
 		return nil, validateMorpheErr
@@ -56,10 +72,11 @@ func morpheStructureToGoStruct(config MorpheCompileConfig, r *registry.Registry,
 		Name:    structure.Name,
 	}
 
-	structFields, fieldsErr := getGoFieldsForMorpheStructure(config.MorpheEnumsConfig.Package, config.MorpheStructuresConfig.Package, r, structure, config.MorpheStructuresConfig.FieldCasing)
+	structFields, fieldsErr := getGoFieldsForMorpheStructure(config.MorpheConfig, r, structure, config.MorpheStructuresConfig.FieldCasing)
 	if fieldsErr != nil {
 		return nil, fieldsErr
 	}
+	applyJSONTagsConfig(config.MorpheStructuresConfig.JSONTags, structure.Name, structFields, nil)
 	structureStruct.Fields = structFields
 
 	structImports, importsErr := getImportsForStructFields(config.MorpheStructuresConfig.Package, structFields)
@@ -68,15 +85,23 @@ func morpheStructureToGoStruct(config MorpheCompileConfig, r *registry.Registry,
 	}
 	structureStruct.Imports = structImports
 
+	if config.MorpheStructuresConfig.ValidateMethods {
+		validateChecks := getStructureValidateChecks(config.MorpheConfig, r, structure)
+		structureStruct.Methods = append(structureStruct.Methods, getValidateMethod(config.MorpheStructuresConfig.Package, config.MorpheStructuresConfig.ReceiverName, structure.Name, validateChecks))
+	}
+	if config.MorpheStructuresConfig.CloneMethods {
+		structureStruct.Methods = append(structureStruct.Methods, getCloneMethods(config.MorpheStructuresConfig.Package, config.MorpheStructuresConfig.ReceiverName, structure.Name, structFields, nil)...)
+	}
+
 	return &structureStruct, nil
 }
 
-func getGoFieldsForMorpheStructure(enumPackage godef.Package, structurePackage godef.Package, r *registry.Registry, structure yaml.Structure, fieldCasing cfg.Casing) ([]godef.StructField, error) {
+func getGoFieldsForMorpheStructure(config cfg.MorpheConfig, r *registry.Registry, structure yaml.Structure, fieldCasing cfg.Casing) ([]godef.StructField, error) {
 	if r == nil {
 		return nil, ErrNoRegistry
 	}
 
-	allFields, fieldsErr := getDirectGoFieldsForMorpheStructure(enumPackage, structurePackage, r.GetAllEnums(), r.GetAllStructures(), structure.Fields, fieldCasing)
+	allFields, fieldsErr := getDirectGoFieldsForMorpheStructure(config, r.GetAllEnums(), r.GetAllStructures(), structure, fieldCasing)
 	if fieldsErr != nil {
 		return nil, fieldsErr
 	}
@@ -84,27 +109,35 @@ func getGoFieldsForMorpheStructure(enumPackage godef.Package, structurePackage g
 	return allFields, nil
 }
 
-func getDirectGoFieldsForMorpheStructure(enumPackage godef.Package, structurePackage godef.Package, allEnums map[string]yaml.Enum, allStructures map[string]yaml.Structure, structureFields map[string]yaml.StructureField, fieldCasing cfg.Casing) ([]godef.StructField, error) {
+func getDirectGoFieldsForMorpheStructure(config cfg.MorpheConfig, allEnums map[string]yaml.Enum, allStructures map[string]yaml.Structure, structure yaml.Structure, fieldCasing cfg.Casing) ([]godef.StructField, error) {
 	allFields := []godef.StructField{}
+	structureFields := structure.Fields
 
-	allFieldNames := core.MapKeysSorted(structureFields)
+	allFieldNames := getOrderedFieldNames(config.MorpheStructuresConfig.FieldOrder, config.DeclarationOrders.Structures[structure.Name], structureFields, nil)
 	for _, fieldName := range allFieldNames {
 		fieldDef := structureFields[fieldName]
 
-		goEnumField := getEnumFieldAsStructFieldType(enumPackage, allEnums, fieldName, string(fieldDef.Type), fieldCasing)
-		if goEnumField.Name != "" && goEnumField.Type != nil {
+		_, hasOverride := config.MorpheTypeOverridesConfig.GetFieldTypeOverride(string(fieldDef.Type))
+		goEnumField := getEnumFieldAsStructFieldType(config.MorpheEnumsConfig.Package, allEnums, fieldName, string(fieldDef.Type), fieldCasing)
+		isOptional := hasAttribute(fieldDef.Attributes, "optional")
+		if !hasOverride && goEnumField.Name != "" && goEnumField.Type != nil {
+			if config.MorpheStructuresConfig.ValidateTags {
+				validateRules := getValidateFieldRules(string(fieldDef.Type), isOptional, hasOverride, allEnums)
+				goEnumField.Tags = append(goEnumField.Tags, buildValidateFieldTags(validateRules, isOptional)...)
+			}
 			allFields = append(allFields, goEnumField)
 			continue
 		}
 
 		// Structure composition: field type references another structure (same package)
-		if allStructures != nil {
+		// The validator validates nested structures without tags
+		if allStructures != nil && !hasOverride {
 			if _, ok := allStructures[string(fieldDef.Type)]; ok {
 				structRefType := godef.GoType(godef.GoTypeStruct{
-					PackagePath: structurePackage.Path,
+					PackagePath: config.MorpheStructuresConfig.Package.Path,
 					Name:        string(fieldDef.Type),
 				})
-				if hasAttribute(fieldDef.Attributes, "optional") {
+				if isOptional {
 					structRefType = godef.GoTypePointer{ValueType: structRefType}
 				}
 				tags := buildFieldTags(fieldName, fieldDef.Attributes, fieldCasing)
@@ -117,17 +150,21 @@ func getDirectGoFieldsForMorpheStructure(enumPackage godef.Package, structurePac
 			}
 		}
 
-		goFieldType, typeSupported := typemap.MorpheStructureFieldToGoField[fieldDef.Type]
+		goFieldType, typeSupported := getStructureFieldGoType(config, fieldDef.Type)
 		if !typeSupported {
-			return nil, ErrUnsupportedMorpheFieldType(fieldDef.Type)
+			return nil, ErrMorpheField(fieldName, ErrUnsupportedMorpheFieldType(fieldDef.Type))
 		}
 
 		// Check for "optional" attribute: wrap type in pointer
-		if hasAttribute(fieldDef.Attributes, "optional") {
+		if isOptional {
 			goFieldType = godef.GoTypePointer{ValueType: goFieldType}
 		}
 
 		tags := buildFieldTags(fieldName, fieldDef.Attributes, fieldCasing)
+		if config.MorpheStructuresConfig.ValidateTags {
+			validateRules := getValidateFieldRules(string(fieldDef.Type), isOptional, hasOverride, allEnums)
+			tags = append(tags, buildValidateFieldTags(validateRules, isOptional)...)
+		}
 		goField := godef.StructField{
 			Name: fieldName,
 			Type: goFieldType,
@@ -660,3 +660,408 @@ func (suite *CompileStructuresTestSuite) TestMorpheStructureToGoStruct_Structure
 	suite.Equal(innerType.Name, "InvoiceLineItem")
 	suite.Equal(innerType.PackagePath, structuresConfig.Package.Path)
 }
+
+func (suite *CompileStructuresTestSuite) TestMorpheStructureToGoStruct_CivilDate() {
+	structuresConfig := cfg.MorpheStructuresConfig{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/structures",
+			Name: "structures",
+		},
+		ReceiverName: "s",
+	}
+	supportConfig := cfg.MorpheSupportConfig{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/support",
+			Name: "support",
+		},
+		CivilDate: true,
+	}
+	config := compile.MorpheCompileConfig{
+		MorpheConfig: cfg.MorpheConfig{
+			MorpheStructuresConfig: structuresConfig,
+			MorpheSupportConfig:    supportConfig,
+		},
+		StructureHooks: hook.CompileMorpheStructure{},
+	}
+
+	structure0 := yaml.Structure{
+		Name: "Period",
+		Fields: map[string]yaml.StructureField{
+			"End": {
+				Type: yaml.StructureFieldTypeDate,
+			},
+			"Start": {
+				Type: yaml.StructureFieldTypeDate,
+			},
+		},
+	}
+
+	r := registry.NewRegistry()
+
+	goStruct, goStructErr := compile.MorpheStructureToGoStruct(config, r, structure0)
+
+	suite.Nil(goStructErr)
+	suite.Equal([]string{"github.com/kalo-build/project/domain/support"}, goStruct.Imports)
+
+	dateType := godef.GoTypeStruct{
+		PackagePath: "github.com/kalo-build/project/domain/support",
+		Name:        "Date",
+	}
+	suite.Len(goStruct.Fields, 2)
+	suite.Equal(dateType, goStruct.Fields[0].Type)
+	suite.Equal(dateType, goStruct.Fields[1].Type)
+}
+
+func (suite *CompileStructuresTestSuite) TestMorpheStructureToGoStruct_RedactSecrets() {
+	structuresConfig := cfg.MorpheStructuresConfig{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/structures",
+			Name: "structures",
+		},
+		ReceiverName: "s",
+	}
+	supportConfig := cfg.MorpheSupportConfig{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/support",
+			Name: "support",
+		},
+		RedactSecrets: true,
+	}
+	config := compile.MorpheCompileConfig{
+		MorpheConfig: cfg.MorpheConfig{
+			MorpheStructuresConfig: structuresConfig,
+			MorpheSupportConfig:    supportConfig,
+		},
+		StructureHooks: hook.CompileMorpheStructure{},
+	}
+
+	structure0 := yaml.Structure{
+		Name: "Credentials",
+		Fields: map[string]yaml.StructureField{
+			"Password": {
+				Type: yaml.StructureFieldTypeProtected,
+			},
+			"Secret": {
+				Type: yaml.StructureFieldTypeSealed,
+			},
+		},
+	}
+
+	r := registry.NewRegistry()
+
+	goStruct, goStructErr := compile.MorpheStructureToGoStruct(config, r, structure0)
+
+	suite.Nil(goStructErr)
+	suite.Len(goStruct.Fields, 2)
+	suite.Equal(godef.GoTypeStruct{
+		PackagePath: "github.com/kalo-build/project/domain/support",
+		Name:        "Protected",
+	}, goStruct.Fields[0].Type)
+	suite.Equal(godef.GoTypeStruct{
+		PackagePath: "github.com/kalo-build/project/domain/support",
+		Name:        "Sealed",
+	}, goStruct.Fields[1].Type)
+}
+
+func (suite *CompileStructuresTestSuite) TestMorpheStructureToGoStruct_ValidateTags() {
+	structuresConfig := cfg.MorpheStructuresConfig{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/structures",
+			Name: "structures",
+		},
+		ReceiverName: "s",
+		ValidateTags: true,
+	}
+	enumsConfig := cfg.MorpheEnumsConfig{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/enums",
+			Name: "enums",
+		},
+	}
+	config := compile.MorpheCompileConfig{
+		MorpheConfig: cfg.MorpheConfig{
+			MorpheStructuresConfig: structuresConfig,
+			MorpheEnumsConfig:      enumsConfig,
+		},
+		StructureHooks: hook.CompileMorpheStructure{},
+	}
+
+	structure0 := yaml.Structure{
+		Name: "Basic",
+		Fields: map[string]yaml.StructureField{
+			"Boolean": {
+				Type: yaml.StructureFieldTypeBoolean,
+			},
+			"Integer": {
+				Type: yaml.StructureFieldTypeInteger,
+			},
+			"Nationality": {
+				Type: "Nationality",
+			},
+			"Priority": {
+				Type: "Priority",
+				Attributes: []string{
+					"optional",
+				},
+			},
+			"Ratio": {
+				Type: "Ratio",
+				Attributes: []string{
+					"optional",
+				},
+			},
+			"Reference": {
+				Type: yaml.StructureFieldTypeUUID,
+				Attributes: []string{
+					"optional",
+				},
+			},
+			"String": {
+				Type: yaml.StructureFieldTypeString,
+			},
+		},
+	}
+
+	enum0 := yaml.Enum{
+		Name: "Nationality",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"US": "American",
+			"DE": "German",
+			"NZ": "New Zealander",
+		},
+	}
+	enum1 := yaml.Enum{
+		Name: "Priority",
+		Type: yaml.EnumTypeInteger,
+		Entries: map[string]any{
+			"Low":  1,
+			"High": 2,
+		},
+	}
+	enum2 := yaml.Enum{
+		Name: "Ratio",
+		Type: yaml.EnumTypeFloat,
+		Entries: map[string]any{
+			"Half":  0.5,
+			"Whole": 1.0,
+		},
+	}
+
+	r := registry.NewRegistry()
+	r.SetEnum("Nationality", enum0)
+	r.SetEnum("Priority", enum1)
+	r.SetEnum("Ratio", enum2)
+
+	structureStruct, structErr := compile.MorpheStructureToGoStruct(config, r, structure0)
+
+	suite.Nil(structErr)
+	suite.NotNil(structureStruct)
+
+	fields := structureStruct.Fields
+	suite.Len(fields, 7)
+
+	suite.Equal("Boolean", fields[0].Name)
+	suite.Nil(fields[0].Tags)
+
+	suite.Equal("Integer", fields[1].Name)
+	suite.Nil(fields[1].Tags)
+
+	suite.Equal("Nationality", fields[2].Name)
+	suite.Equal([]string{`validate:"required,oneof=German 'New Zealander' American"`}, fields[2].Tags)
+
+	suite.Equal("Priority", fields[3].Name)
+	suite.Equal([]string{`validate:"omitempty,oneof=2 1"`}, fields[3].Tags)
+
+	suite.Equal("Ratio", fields[4].Name)
+	suite.Equal([]string{`validate:"omitempty,oneof=0.5 1"`}, fields[4].Tags)
+
+	suite.Equal("Reference", fields[5].Name)
+	suite.Equal([]string{`morphe:"optional"`, `validate:"omitempty,uuid"`}, fields[5].Tags)
+
+	suite.Equal("String", fields[6].Name)
+	suite.Equal([]string{`validate:"required"`}, fields[6].Tags)
+}
+
+func (suite *CompileStructuresTestSuite) TestMorpheStructureToGoStruct_ValidateMethods() {
+	structuresConfig := cfg.MorpheStructuresConfig{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/structures",
+			Name: "structures",
+		},
+		ReceiverName:    "s",
+		ValidateMethods: true,
+	}
+	enumsConfig := cfg.MorpheEnumsConfig{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/enums",
+			Name: "enums",
+		},
+	}
+	config := compile.MorpheCompileConfig{
+		MorpheConfig: cfg.MorpheConfig{
+			MorpheStructuresConfig: structuresConfig,
+			MorpheEnumsConfig:      enumsConfig,
+		},
+		StructureHooks: hook.CompileMorpheStructure{},
+	}
+
+	structure0 := yaml.Structure{
+		Name: "Shipment",
+		Fields: map[string]yaml.StructureField{
+			"Destination": {
+				Type: "Address",
+			},
+			"Origin": {
+				Type: "Address",
+				Attributes: []string{
+					"optional",
+				},
+			},
+			"Priority": {
+				Type: "Priority",
+				Attributes: []string{
+					"optional",
+				},
+			},
+			"Reference": {
+				Type: yaml.StructureFieldTypeString,
+			},
+		},
+	}
+	structure1 := yaml.Structure{
+		Name: "Address",
+		Fields: map[string]yaml.StructureField{
+			"Street": {
+				Type: yaml.StructureFieldTypeString,
+			},
+		},
+	}
+
+	enum0 := yaml.Enum{
+		Name: "Priority",
+		Type: yaml.EnumTypeInteger,
+		Entries: map[string]any{
+			"Low":  1,
+			"High": 2,
+		},
+	}
+
+	r := registry.NewRegistry()
+	r.SetEnum("Priority", enum0)
+	r.SetStructure("Shipment", structure0)
+	r.SetStructure("Address", structure1)
+
+	structureStruct, structErr := compile.MorpheStructureToGoStruct(config, r, structure0)
+
+	suite.Nil(structErr)
+	suite.NotNil(structureStruct)
+
+	structMethods := structureStruct.Methods
+	suite.Len(structMethods, 1)
+
+	validateMethod := structMethods[0]
+	suite.Equal("s", validateMethod.ReceiverName)
+	suite.Equal(godef.GoTypeStruct{
+		PackagePath: "github.com/kalo-build/project/domain/structures",
+		Name:        "Shipment",
+	}, validateMethod.ReceiverType)
+	suite.Equal("Validate", validateMethod.Name)
+	suite.Empty(validateMethod.Parameters)
+	suite.Equal([]godef.GoType{godef.GoTypeError}, validateMethod.ReturnTypes)
+	suite.Equal([]string{
+		"\tvar errs ValidationErrors",
+		"\terrs = errs.appendNested(\"Destination\", s.Destination.Validate())",
+		"\tif s.Origin != nil {",
+		"\t\terrs = errs.appendNested(\"Origin\", s.Origin.Validate())",
+		"\t}",
+		"\tif s.Priority != 0 && !s.Priority.IsValid() {",
+		"\t\terrs = append(errs, ValidationError{Path: \"Priority\", Message: \"invalid value\"})",
+		"\t}",
+		"\treturn errs.orNil()",
+	}, validateMethod.BodyLines)
+}
+
+func (suite *CompileStructuresTestSuite) TestMorpheStructureToGoStruct_CloneMethods() {
+	structuresConfig := cfg.MorpheStructuresConfig{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/structures",
+			Name: "structures",
+		},
+		ReceiverName: "s",
+		CloneMethods: true,
+	}
+	config := compile.MorpheCompileConfig{
+		MorpheConfig: cfg.MorpheConfig{
+			MorpheStructuresConfig: structuresConfig,
+		},
+		StructureHooks: hook.CompileMorpheStructure{},
+	}
+
+	structure0 := yaml.Structure{
+		Name: "Shipment",
+		Fields: map[string]yaml.StructureField{
+			"Destination": {
+				Type: "Address",
+			},
+			"Note": {
+				Type: yaml.StructureFieldTypeString,
+				Attributes: []string{
+					"optional",
+				},
+			},
+			"Origin": {
+				Type: "Address",
+				Attributes: []string{
+					"optional",
+				},
+			},
+			"Reference": {
+				Type: yaml.StructureFieldTypeString,
+			},
+		},
+	}
+	structure1 := yaml.Structure{
+		Name: "Address",
+		Fields: map[string]yaml.StructureField{
+			"Street": {
+				Type: yaml.StructureFieldTypeString,
+			},
+		},
+	}
+
+	r := registry.NewRegistry()
+	r.SetStructure("Shipment", structure0)
+	r.SetStructure("Address", structure1)
+
+	structureStruct, structErr := compile.MorpheStructureToGoStruct(config, r, structure0)
+
+	suite.Nil(structErr)
+	suite.NotNil(structureStruct)
+	suite.Len(structureStruct.Methods, 2)
+
+	receiverType := godef.GoTypeStruct{
+		PackagePath: "github.com/kalo-build/project/domain/structures",
+		Name:        "Shipment",
+	}
+	cloneMethod := structureStruct.Methods[0]
+	suite.Equal("Clone", cloneMethod.Name)
+	suite.Equal([]godef.GoType{receiverType}, cloneMethod.ReturnTypes)
+	suite.Equal([]string{"\treturn s.cloneWith(map[any]any{})"}, cloneMethod.BodyLines)
+
+	cloneWithMethod := structureStruct.Methods[1]
+	suite.Equal("cloneWith", cloneWithMethod.Name)
+	suite.Equal(map[string]godef.GoType{
+		"clones": godef.GoTypeMap{
+			KeyType:   godef.GoTypeInterface{Name: "any"},
+			ValueType: godef.GoTypeInterface{Name: "any"},
+		},
+	}, cloneWithMethod.Parameters)
+	suite.Equal([]string{
+		"\tclone := s",
+		"\tclone.Destination = s.Destination.cloneWith(clones)",
+		"\tclone.Note = cloneValue(s.Note)",
+		"\tclone.Origin = clonePointer(s.Origin, clones, Address.cloneWith)",
+		"\treturn clone",
+	}, cloneWithMethod.BodyLines)
+}
@@ -0,0 +1,26 @@
+package compile
+
+import (
+	"fmt"
+
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+)
+
+// AllSupportDefinitions returns the rendered contents of all support definitions enabled in the config (definition name -> contents).
+func AllSupportDefinitions(config cfg.MorpheSupportConfig) map[string]string {
+	allSupportDefs := map[string]string{}
+	if config.IsGeneratedDate() {
+		allSupportDefs["Date"] = getSupportFileContents(config, supportDateSource)
+	}
+	if config.RedactSecrets {
+		allSupportDefs["Redacted"] = getSupportFileContents(config, supportRedactedSource)
+		allSupportDefs["Protected"] = getSupportFileContents(config, getSupportSecretSource("Protected", "ie. a password hash"))
+		allSupportDefs["Sealed"] = getSupportFileContents(config, getSupportSecretSource("Sealed", "ie. an encrypted API token"))
+	}
+	return allSupportDefs
+}
+
+func getSupportFileContents(config cfg.MorpheSupportConfig, source string) string {
+	return fmt.Sprintf("%s\n\npackage %s\n\n%s", gofile.GeneratedFileHeader, config.Package.Name, source)
+}
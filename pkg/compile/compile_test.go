@@ -1,8 +1,12 @@
 package compile_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -13,6 +17,7 @@ import (
 	"github.com/kalo-build/plugin-morphe-go-struct/internal/testutils"
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile"
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
 )
 
 type CompileTestSuite struct {
@@ -45,11 +50,33 @@ func (suite *CompileTestSuite) TearDownTest() {
 	suite.TestDirPath = ""
 }
 
-func (suite *CompileTestSuite) TestMorpheToGo() {
-	workingDirPath := suite.TestDirPath + "/working"
-	suite.Nil(os.Mkdir(workingDirPath, 0644))
-	defer os.RemoveAll(workingDirPath)
+// compileConfigToggle changes the compile config of a test, ie. enables an option.
+type compileConfigToggle func(config *compile.MorpheCompileConfig)
 
+// withRegistry compiles another registry than the minimal one.
+func withRegistry(registryDirPath string) compileConfigToggle {
+	return func(config *compile.MorpheCompileConfig) {
+		config.MorpheLoadRegistryConfig = rcfg.MorpheLoadRegistryConfig{
+			RegistryEnumsDirPath:      filepath.Join(registryDirPath, "enums"),
+			RegistryStructuresDirPath: filepath.Join(registryDirPath, "structures"),
+			RegistryModelsDirPath:     filepath.Join(registryDirPath, "models"),
+			RegistryEntitiesDirPath:   filepath.Join(registryDirPath, "entities"),
+		}
+	}
+}
+
+// withGeneratedPackages places all packages in the "generated" module of runGeneratedTests.
+func withGeneratedPackages(config *compile.MorpheCompileConfig) {
+	config.MorpheModelsConfig.Package.Path = "generated/models"
+	config.MorpheStructuresConfig.Package.Path = "generated/structures"
+	config.MorpheEnumsConfig.Package.Path = "generated/enums"
+	config.MorpheEntitiesConfig.Package.Path = "generated/entities"
+	config.MorpheSupportConfig.Package.Path = "generated/support"
+}
+
+// getCompileConfig returns the config compiling the minimal registry into the working directory, or into the output
+// filesystem if it is set. The toggles are applied in order.
+func (suite *CompileTestSuite) getCompileConfig(workingDirPath string, outputFS gofile.OutputFS, allToggles ...compileConfigToggle) compile.MorpheCompileConfig {
 	config := compile.MorpheCompileConfig{
 		MorpheLoadRegistryConfig: rcfg.MorpheLoadRegistryConfig{
 			RegistryEnumsDirPath:      suite.EnumsDirPath,
@@ -85,27 +112,54 @@ func (suite *CompileTestSuite) TestMorpheToGo() {
 				},
 				ReceiverName: "e",
 			},
+			MorpheSupportConfig: cfg.MorpheSupportConfig{
+				Package: godef.Package{
+					Path: "github.com/kalo-build/dummy/support",
+					Name: "support",
+				},
+			},
 		},
 
 		ModelWriter: &compile.MorpheStructFileWriter{
 			Type:          compile.MorpheStructTypeModels,
-			TargetDirPath: workingDirPath + "/models",
+			TargetDirPath: path.Join(workingDirPath, "models"),
+			OutputFS:      outputFS,
 		},
 
 		StructureWriter: &compile.MorpheStructFileWriter{
 			Type:          compile.MorpheStructTypeStructures,
-			TargetDirPath: workingDirPath + "/structures",
+			TargetDirPath: path.Join(workingDirPath, "structures"),
+			OutputFS:      outputFS,
 		},
 
 		EnumWriter: &compile.MorpheEnumFileWriter{
-			TargetDirPath: workingDirPath + "/enums",
+			TargetDirPath: path.Join(workingDirPath, "enums"),
+			OutputFS:      outputFS,
 		},
 
 		EntityWriter: &compile.MorpheStructFileWriter{
 			Type:          compile.MorpheStructTypeEntities,
-			TargetDirPath: workingDirPath + "/entities",
+			TargetDirPath: path.Join(workingDirPath, "entities"),
+			OutputFS:      outputFS,
+		},
+
+		SupportWriter: &compile.MorpheSupportFileWriter{
+			TargetDirPath: path.Join(workingDirPath, "support"),
+			OutputFS:      outputFS,
 		},
 	}
+	for _, toggle := range allToggles {
+		toggle(&config)
+	}
+	return config
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo() {
+	workingDirPath := suite.TestDirPath + "/working"
+	suite.Nil(os.Mkdir(workingDirPath, 0644))
+	defer os.RemoveAll(workingDirPath)
+
+	config := suite.getCompileConfig(workingDirPath, nil)
 
 	compileErr := compile.MorpheToGo(config)
 
@@ -237,3 +291,816 @@ func (suite *CompileTestSuite) TestMorpheToGo() {
 	suite.FileExists(commentPath)
 	suite.FileEquals(commentPath, gtCommentPath)
 }
+
+func (suite *CompileTestSuite) TestMorpheToGo_RemoveStaleFiles() {
+	workingDirPath := suite.TestDirPath + "/working"
+	suite.Nil(os.Mkdir(workingDirPath, 0644))
+	defer os.RemoveAll(workingDirPath)
+
+	modelsDirPath := workingDirPath + "/models"
+	structuresDirPath := workingDirPath + "/structures"
+	suite.Nil(os.MkdirAll(modelsDirPath, 0755))
+	suite.Nil(os.MkdirAll(structuresDirPath, 0755))
+
+	staleModelPath := modelsDirPath + "/person_id_old_name.go"
+	staleModelContents := gofile.GeneratedFileHeader + "\n\npackage models\n\ntype PersonIDOldName struct{}\n"
+	suite.Nil(os.WriteFile(staleModelPath, []byte(staleModelContents), 0644))
+
+	staleStructurePath := structuresDirPath + "/removed_structure.go"
+	staleStructureContents := gofile.GeneratedFileHeader + "\n\npackage structures\n\ntype RemovedStructure struct{}\n"
+	suite.Nil(os.WriteFile(staleStructurePath, []byte(staleStructureContents), 0644))
+
+	handWrittenPath := modelsDirPath + "/person_helpers.go"
+	handWrittenContents := "package models\n\nfunc (m Person) FullName() string {\n\treturn m.FirstName + \" \" + m.LastName\n}\n"
+	suite.Nil(os.WriteFile(handWrittenPath, []byte(handWrittenContents), 0644))
+
+	config := suite.getCompileConfig(workingDirPath, nil)
+	config.RemoveStaleFiles = true
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	suite.NoFileExists(staleModelPath)
+	suite.NoFileExists(staleStructurePath)
+	suite.FileExists(handWrittenPath)
+	suite.FileExists(modelsDirPath + "/person.go")
+	suite.FileExists(modelsDirPath + "/person_id_name.go")
+	suite.FileExists(structuresDirPath + "/address.go")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_KeepStaleFiles() {
+	workingDirPath := suite.TestDirPath + "/working"
+	suite.Nil(os.Mkdir(workingDirPath, 0644))
+	defer os.RemoveAll(workingDirPath)
+
+	modelsDirPath := workingDirPath + "/models"
+	suite.Nil(os.MkdirAll(modelsDirPath, 0755))
+
+	staleModelPath := modelsDirPath + "/person_id_old_name.go"
+	staleModelContents := gofile.GeneratedFileHeader + "\n\npackage models\n\ntype PersonIDOldName struct{}\n"
+	suite.Nil(os.WriteFile(staleModelPath, []byte(staleModelContents), 0644))
+
+	config := suite.getCompileConfig(workingDirPath, nil)
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+	suite.FileExists(staleModelPath)
+}
+
+func (suite *CompileTestSuite) TestCheckMorpheToGo_UpToDate() {
+	workingDirPath := suite.TestDirPath + "/working"
+	suite.Nil(os.Mkdir(workingDirPath, 0644))
+	defer os.RemoveAll(workingDirPath)
+
+	config := suite.getCompileConfig(workingDirPath, nil)
+
+	compileErr := compile.MorpheToGo(config)
+	suite.NoError(compileErr)
+
+	checkResult, checkErr := compile.CheckMorpheToGo(config)
+
+	suite.NoError(checkErr)
+	suite.True(checkResult.IsClean())
+	suite.Empty(checkResult.String())
+}
+
+func (suite *CompileTestSuite) TestCheckMorpheToGo_OutOfDate() {
+	workingDirPath := suite.TestDirPath + "/working"
+	suite.Nil(os.Mkdir(workingDirPath, 0644))
+	defer os.RemoveAll(workingDirPath)
+
+	config := suite.getCompileConfig(workingDirPath, nil)
+
+	compileErr := compile.MorpheToGo(config)
+	suite.NoError(compileErr)
+
+	modelsDirPath := workingDirPath + "/models"
+	changedModelPath := modelsDirPath + "/contact.go"
+	changedModelContents := gofile.GeneratedFileHeader + "\n\npackage models\n\ntype Contact struct {\n\tID uint\n}\n"
+	suite.Nil(os.WriteFile(changedModelPath, []byte(changedModelContents), 0644))
+
+	missingEnumPath := workingDirPath + "/enums/nationality.go"
+	suite.Nil(os.Remove(missingEnumPath))
+
+	extraModelPath := modelsDirPath + "/person_id_old_name.go"
+	extraModelContents := gofile.GeneratedFileHeader + "\n\npackage models\n\ntype PersonIDOldName struct{}\n"
+	suite.Nil(os.WriteFile(extraModelPath, []byte(extraModelContents), 0644))
+
+	handWrittenPath := modelsDirPath + "/person_helpers.go"
+	suite.Nil(os.WriteFile(handWrittenPath, []byte("package models\n"), 0644))
+
+	checkResult, checkErr := compile.CheckMorpheToGo(config)
+
+	suite.NoError(checkErr)
+	suite.False(checkResult.IsClean())
+
+	suite.Len(checkResult.Changed, 1)
+	suite.Equal(changedModelPath, checkResult.Changed[0].FilePath)
+	suite.Contains(checkResult.Changed[0].Diff, "+\tEmail string\n")
+	suite.Contains(checkResult.Changed[0].Diff, "+\tPhone string\n")
+
+	suite.Equal([]string{missingEnumPath}, checkResult.Missing)
+	suite.Equal([]string{extraModelPath}, checkResult.Extra)
+
+	// Check mode never writes or removes files
+	existingModelContents, readErr := os.ReadFile(changedModelPath)
+	suite.NoError(readErr)
+	suite.Equal(changedModelContents, string(existingModelContents))
+	suite.NoFileExists(missingEnumPath)
+	suite.FileExists(extraModelPath)
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_MemFS() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS)
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	allFiles := outputFS.Files()
+	suite.NotContains(allFiles, "support/date.go")
+
+	allFilePaths := []string{
+		"models/person.go",
+		"models/person_id_name.go",
+		"models/comment.go",
+		"enums/nationality.go",
+		"enums/universal_number.go",
+		"structures/address.go",
+		"entities/person.go",
+		"entities/company_id_primary.go",
+	}
+	for _, filePath := range allFilePaths {
+		suite.Contains(allFiles, filePath)
+		gtContents, gtReadErr := os.ReadFile(filepath.Join(suite.TestGroundTruthDirPath, filePath))
+		suite.NoError(gtReadErr)
+		suite.Equal(string(gtContents), string(allFiles[filePath]), filePath)
+	}
+
+	suite.Nil(outputFS.WriteFile("models/person.go", []byte(gofile.GeneratedFileHeader+"\n\npackage models\n")))
+	suite.Nil(outputFS.Remove("enums/nationality.go"))
+
+	checkResult, checkErr := compile.CheckMorpheToGo(config)
+
+	suite.NoError(checkErr)
+	suite.Len(checkResult.Changed, 1)
+	suite.Equal("models/person.go", checkResult.Changed[0].FilePath)
+	suite.Equal([]string{"enums/nationality.go"}, checkResult.Missing)
+	suite.Empty(checkResult.Extra)
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_EnumMarshalling() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, func(config *compile.MorpheCompileConfig) {
+		config.MorpheEnumsConfig.JSONMarshalling = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	enumContents := string(outputFS.Files()["enums/universal_number.go"])
+	suite.Contains(enumContents, "\t\"encoding/json\"\n")
+	suite.Contains(enumContents, "type InvalidUniversalNumberError struct {")
+	suite.Contains(enumContents, "func (e UniversalNumber) MarshalJSON() ([]byte, error) {")
+	suite.Contains(enumContents, "func (e *UniversalNumber) UnmarshalJSON(data []byte) error {")
+	suite.NotContains(enumContents, "MarshalText")
+	suite.NotContains(enumContents, "driver.Valuer")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_EnumParse() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, withRegistry(filepath.Join(suite.TestDirPath, "registry", "enum-marshalling")), withGeneratedPackages, func(config *compile.MorpheCompileConfig) {
+		config.MorpheEnumsConfig.JSONMarshalling = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	priorityContents := string(outputFS.Files()["enums/priority.go"])
+	suite.Contains(priorityContents, "func ParsePriority(name string) (Priority, error) {\n")
+	suite.Contains(priorityContents, "func PriorityFromValue(value int) (Priority, error) {\n")
+	colorContents := string(outputFS.Files()["enums/color.go"])
+	suite.Contains(colorContents, "func ParseColor(name string) (Color, error) {\n")
+	suite.Contains(colorContents, "func ColorFromValue(value string) (Color, error) {\n")
+	suite.runGeneratedTests(outputFS, "enums/parse_test.go")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_EnumMarshallingRoundTrip() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, withRegistry(filepath.Join(suite.TestDirPath, "registry", "enum-marshalling")), withGeneratedPackages, func(config *compile.MorpheCompileConfig) {
+		config.MorpheEnumsConfig.TextMarshalling = true
+		config.MorpheEnumsConfig.JSONMarshalling = true
+		config.MorpheEnumsConfig.SQLMarshalling = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	priorityContents := string(outputFS.Files()["enums/priority.go"])
+	suite.Contains(priorityContents, "\treturn []byte(strconv.FormatInt(int64(e), 10)), nil\n")
+	suite.Contains(string(outputFS.Files()["enums/ratio.go"]), "\treturn []byte(strconv.FormatFloat(float64(e), 'g', -1, 64)), nil\n")
+	suite.runGeneratedTests(outputFS, "enums/marshalling_test.go")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_CivilDate() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, func(config *compile.MorpheCompileConfig) {
+		config.MorpheSupportConfig.CivilDate = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	allFiles := outputFS.Files()
+	suite.Contains(allFiles, "support/date.go")
+
+	dateContents := string(allFiles["support/date.go"])
+	suite.True(strings.HasPrefix(dateContents, gofile.GeneratedFileHeader+"\n\npackage support\n"))
+	suite.Contains(dateContents, "type Date struct {")
+	suite.Contains(dateContents, "func (d *Date) Scan(src any) error {")
+
+	config.MorpheSupportConfig.CivilDate = false
+	config.RemoveStaleFiles = true
+
+	compileErr = compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+	suite.NotContains(outputFS.Files(), "support/date.go")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_CivilDateRoundTrip() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, withGeneratedPackages, func(config *compile.MorpheCompileConfig) {
+		config.MorpheSupportConfig.CivilDate = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+	suite.runGeneratedTests(outputFS, "support/date_test.go")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_RedactSecrets() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, func(config *compile.MorpheCompileConfig) {
+		config.MorpheSupportConfig.RedactSecrets = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	allFiles := outputFS.Files()
+	for _, filePath := range []string{"support/redacted.go", "support/protected.go", "support/sealed.go"} {
+		suite.Contains(allFiles, filePath)
+	}
+	suite.Contains(string(allFiles["support/redacted.go"]), `const Redacted = "[REDACTED]"`)
+
+	protectedContents := string(allFiles["support/protected.go"])
+	suite.Contains(protectedContents, "type Protected struct {")
+	suite.Contains(protectedContents, "func (s Protected) Raw() string {")
+	suite.Contains(protectedContents, "func (s Protected) Format(state fmt.State, verb rune) {")
+
+	sealedContents := string(allFiles["support/sealed.go"])
+	suite.Contains(sealedContents, "type Sealed struct {")
+	suite.Contains(sealedContents, "func (s Sealed) MarshalJSON() ([]byte, error) {")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_RedactSecretsRoundTrip() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, withGeneratedPackages, func(config *compile.MorpheCompileConfig) {
+		config.MorpheSupportConfig.RedactSecrets = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+	suite.runGeneratedTests(outputFS, "support/secret_test.go")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_TypedIDs() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, func(config *compile.MorpheCompileConfig) {
+		config.MorpheModelsConfig.TypedIDs = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	allFiles := outputFS.Files()
+	// The ID types are declared next to their structs
+	suite.NotContains(allFiles, "models/person_id.go")
+
+	personContents := string(allFiles["models/person.go"])
+	suite.Contains(personContents, "// PersonID is the primary identifier type of Person.\ntype PersonID uint\n")
+	suite.Contains(personContents, "\tID                PersonID\n")
+	suite.Contains(personContents, "\tCompanyID         CompanyID\n")
+	suite.Contains(personContents, "\tNoteIDs           []CommentID\n")
+	suite.Contains(string(allFiles["models/person_id_primary.go"]), "\tID PersonID\n")
+	suite.NotContains(string(allFiles["models/person_id_primary.go"]), "type PersonID ")
+	suite.Contains(string(allFiles["entities/person.go"]), "\tID          uint `morphe:\"immutable\"`\n")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_TypedPolyRelations() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, func(config *compile.MorpheCompileConfig) {
+		config.MorpheModelsConfig.TypedPolyRelations = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	allFiles := outputFS.Files()
+	suite.Contains(allFiles, "models/commentable.go")
+
+	commentableContents := string(allFiles["models/commentable.go"])
+	suite.Contains(commentableContents, "\tCommentableTypePerson  CommentableType = \"Person\"\n")
+	suite.Contains(commentableContents, "type Commentable interface {")
+
+	commentContents := string(allFiles["models/comment.go"])
+	suite.Contains(commentContents, "func (m *Comment) SetCommentable(commentable Commentable) {")
+	suite.Contains(commentContents, "func (m Comment) CommentableAsPerson() (Person, bool) {")
+	suite.Contains(commentContents, "\tcase *Person:\n\t\tif person != nil {\n\t\t\treturn *person, true\n")
+	suite.Contains(string(allFiles["models/person.go"]), "func (m Person) commentableType() CommentableType {")
+
+	config.MorpheModelsConfig.TypedPolyRelations = false
+	config.RemoveStaleFiles = true
+
+	compileErr = compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+	suite.NotContains(outputFS.Files(), "models/commentable.go")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_ValidateMethods() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, func(config *compile.MorpheCompileConfig) {
+		config.MorpheModelsConfig.ValidateMethods = true
+		config.MorpheStructuresConfig.ValidateMethods = true
+		config.MorpheEntitiesConfig.ValidateMethods = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	allFiles := outputFS.Files()
+	for _, packageName := range []string{"models", "structures", "entities"} {
+		suite.Contains(allFiles, packageName+"/validation_errors.go")
+		validationContents := string(allFiles[packageName+"/validation_errors.go"])
+		suite.Contains(validationContents, "package "+packageName+"\n")
+		suite.Contains(validationContents, "type ValidationErrors []ValidationError\n")
+	}
+
+	personContents := string(allFiles["models/person.go"])
+	suite.Contains(personContents, "func (m Person) Validate() error {")
+	suite.Contains(personContents, "\tif !m.Nationality.IsValid() {\n")
+	suite.Contains(personContents, "\tif m.Company == nil {\n")
+
+	config.MorpheModelsConfig.ValidateMethods = false
+	config.RemoveStaleFiles = true
+
+	compileErr = compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+	suite.NotContains(outputFS.Files(), "models/validation_errors.go")
+	suite.Contains(outputFS.Files(), "structures/validation_errors.go")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_CloneMethods() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, func(config *compile.MorpheCompileConfig) {
+		config.MorpheModelsConfig.CloneMethods = true
+		config.MorpheStructuresConfig.CloneMethods = true
+		config.MorpheEntitiesConfig.CloneMethods = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	allFiles := outputFS.Files()
+	for _, packageName := range []string{"models", "structures", "entities"} {
+		cloneContents := string(allFiles[packageName+"/clone.go"])
+		suite.Contains(cloneContents, "package "+packageName+"\n")
+		suite.Contains(cloneContents, "func clonePointer[T any](value *T, clones map[any]any, cloneWith func(T, map[any]any) T) *T {\n")
+	}
+
+	companyContents := string(allFiles["models/company.go"])
+	suite.Contains(companyContents, "func (m Company) Clone() Company {\n")
+	suite.Contains(companyContents, "\tclone.People = cloneStructs(m.People, clones, Person.cloneWith)\n")
+
+	entityContents := string(allFiles["entities/person.go"])
+	suite.Contains(entityContents, "\tclone.Company = clonePointer(e.Company, clones, Company.cloneWith)\n")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_CloneMethodsTypedPolyRelations() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, withGeneratedPackages, func(config *compile.MorpheCompileConfig) {
+		config.MorpheModelsConfig.CloneMethods = true
+		config.MorpheModelsConfig.TypedPolyRelations = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	commentContents := string(outputFS.Files()["models/comment.go"])
+	suite.Contains(commentContents, "\tswitch commentable := m.Commentable.(type) {\n"+
+		"\tcase Company:\n"+
+		"\t\tclone.Commentable = commentable.cloneWith(clones)\n"+
+		"\tcase *Company:\n"+
+		"\t\tclone.Commentable = clonePointer(commentable, clones, Company.cloneWith)\n"+
+		"\tcase Person:\n")
+	suite.runGeneratedTests(outputFS, "models/comment_test.go")
+}
+
+func (suite *CompileTestSuite) TestGetAllGeneratedFiles() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS)
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	allFiles := outputFS.Files()
+	allGeneratedFiles := compile.GetAllGeneratedFiles(config)
+	suite.Len(allGeneratedFiles, len(allFiles))
+	for fileIdx, generatedFile := range allGeneratedFiles {
+		if fileIdx > 0 {
+			suite.Less(allGeneratedFiles[fileIdx-1].Path, generatedFile.Path)
+		}
+		fileContents, isWritten := allFiles[generatedFile.Path]
+		suite.True(isWritten, generatedFile.Path)
+		contentsHash := sha256.Sum256(fileContents)
+		suite.Equal("sha256:"+hex.EncodeToString(contentsHash[:]), generatedFile.Hash)
+	}
+	suite.Contains(allGeneratedFiles, gofile.NewGeneratedFile("models", "Person", allFiles["models/person.go"]))
+
+	suite.Empty(compile.GetAllGeneratedFiles(config))
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_AggregateErrors() {
+	registryDirPath := suite.T().TempDir()
+	modelsDirPath := filepath.Join(registryDirPath, "models")
+	suite.Nil(os.MkdirAll(modelsDirPath, 0755))
+	getModelContents := func(modelName string, relationName string) string {
+		return "name: " + modelName + "\n" +
+			"fields:\n" +
+			"  ID:\n" +
+			"    type: AutoIncrement\n" +
+			"identifiers:\n" +
+			"  primary: ID\n" +
+			"related:\n" +
+			"  " + relationName + ":\n" +
+			"    type: ForOne\n"
+	}
+	suite.Nil(os.WriteFile(filepath.Join(modelsDirPath, "person.mod"), []byte(getModelContents("Person", "Team")), 0644))
+	suite.Nil(os.WriteFile(filepath.Join(modelsDirPath, "company.mod"), []byte(getModelContents("Company", "Office")), 0644))
+
+	outputFS := &gofile.MemFS{}
+	config := suite.getCompileConfig("", outputFS, withRegistry(registryDirPath), func(config *compile.MorpheCompileConfig) {
+		config.AggregateErrors = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	var compileErrs compile.CompileErrors
+	suite.ErrorAs(compileErr, &compileErrs)
+	suite.Len(compileErrs, 2)
+	suite.Equal("Company", compileErrs[0].Definition)
+	suite.Equal("Office", compileErrs[0].Field)
+	suite.Equal("Person", compileErrs[1].Definition)
+	suite.Equal("Team", compileErrs[1].Field)
+	suite.Empty(outputFS.Files())
+
+	suite.Equal(compile.SourcePosition{
+		FilePath: filepath.Join(modelsDirPath, "company.mod"),
+		Line:     8,
+		Column:   3,
+	}, compileErrs[0].Position)
+	suite.True(strings.HasPrefix(compileErrs[1].Error(), filepath.Join(modelsDirPath, "person.mod")+":8:3: model 'Person' field 'Team': "))
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_SourcePositions() {
+	registryDirPath := suite.T().TempDir()
+	modelsDirPath := filepath.Join(registryDirPath, "models")
+	suite.Nil(os.MkdirAll(modelsDirPath, 0755))
+	modelContents := "name: Person\n" +
+		"fields:\n" +
+		"  ID:\n" +
+		"    type: AutoIncrement\n" +
+		"identifiers:\n" +
+		"  primary: UUID\n"
+	modelPath := filepath.Join(modelsDirPath, "person.mod")
+	suite.Nil(os.WriteFile(modelPath, []byte(modelContents), 0644))
+
+	outputFS := &gofile.MemFS{}
+	config := suite.getCompileConfig("", outputFS, withRegistry(registryDirPath))
+
+	compileErr := compile.MorpheToGo(config)
+
+	var definitionErr *compile.CompileError
+	suite.ErrorAs(compileErr, &definitionErr)
+	suite.Equal("Person", definitionErr.Definition)
+	suite.Equal("", definitionErr.Field)
+	suite.Equal(compile.SourcePosition{
+		FilePath: modelPath,
+		Line:     1,
+		Column:   7,
+	}, definitionErr.Position)
+	suite.True(strings.HasPrefix(compileErr.Error(), modelPath+":1:7: model 'Person': "))
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_DiffMethods() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, func(config *compile.MorpheCompileConfig) {
+		config.MorpheModelsConfig.DiffMethods = true
+		config.MorpheEntitiesConfig.DiffMethods = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	allFiles := outputFS.Files()
+	for _, packageName := range []string{"models", "entities"} {
+		fieldChangeContents := string(allFiles[packageName+"/field_change.go"])
+		suite.Contains(fieldChangeContents, "package "+packageName+"\n")
+		suite.Contains(fieldChangeContents, "type FieldChange struct {\n")
+	}
+	suite.NotContains(allFiles, "structures/field_change.go")
+
+	personContents := string(allFiles["models/person.go"])
+	suite.Contains(personContents, "func (m Person) Equal(other Person) bool {\n")
+	suite.Contains(personContents, "func (m Person) Diff(other Person) []FieldChange {\n")
+	suite.Contains(personContents, "\tif m.CompanyID != other.CompanyID {\n")
+	suite.NotContains(personContents, "m.Company != other.Company")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_DiffMethodsTypeOverrides() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, withRegistry(filepath.Join(suite.TestDirPath, "registry", "diff-methods")), withGeneratedPackages, func(config *compile.MorpheCompileConfig) {
+		config.MorpheModelsConfig.DiffMethods = true
+		config.MorpheTypeOverridesConfig.ModelFields = map[string]cfg.TypeOverride{
+			"Invoice.Amount":   {PackagePath: "generated/money", Name: "Amount"},
+			"Invoice.Discount": {PackagePath: "generated/money", Name: "Amount"},
+		}
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	invoiceContents := string(outputFS.Files()["models/invoice.go"])
+	suite.Contains(invoiceContents, "\tif !equalValue(m.Amount, other.Amount) {\n")
+	suite.Contains(invoiceContents, "\tif !equalPointerFunc(m.Discount, other.Discount, equalValue[money.Amount]) {\n")
+	suite.runGeneratedTests(outputFS, "money/amount.go", "models/invoice_test.go")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_Constructors() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, func(config *compile.MorpheCompileConfig) {
+		config.MorpheModelsConfig.Constructors = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	constructorContents := string(outputFS.Files()["models/new_person.go"])
+	suite.Contains(constructorContents, "func NewPerson(firstName string, lastName string, nationality enums.Nationality, companyID uint, personalContactID uint, workContactID uint) Person {\n")
+
+	personContents := string(outputFS.Files()["models/person.go"])
+	suite.Contains(personContents, "func (m Person) WithID(id uint) Person {\n")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_EntityModelMappers() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, func(config *compile.MorpheCompileConfig) {
+		config.MorpheEntitiesConfig.ModelMappers = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	personMapperContents := string(outputFS.Files()["entities/person_from_model.go"])
+	suite.Contains(personMapperContents, "func PersonFromModel(m models.Person) (Person, error) {\n")
+	suite.Contains(personMapperContents, "\tif m.ContactInfo == nil {\n")
+	suite.Contains(personMapperContents, "\tentity.Email = m.ContactInfo.Email\n")
+	suite.Contains(personMapperContents, "\tentity.CompanyID = m.CompanyID\n")
+
+	companyMapperContents := string(outputFS.Files()["entities/company_from_model.go"])
+	suite.Contains(companyMapperContents, "\tentity.PersonIDs = append([]uint(nil), m.PersonIDs...)\n")
+
+	config.MorpheEntitiesConfig.ModelMappers = false
+	config.RemoveStaleFiles = true
+
+	compileErr = compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+	suite.NotContains(outputFS.Files(), "entities/person_from_model.go")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_EntityModelMappersOptionalFields() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, withRegistry(filepath.Join(suite.TestDirPath, "registry", "model-mappers")), withGeneratedPackages, func(config *compile.MorpheCompileConfig) {
+		config.MorpheEntitiesConfig.ModelMappers = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+	suite.runGeneratedTests(outputFS, "entities/person_from_model_test.go")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_EntityModelMappersAggregateErrors() {
+	registryDirPath := suite.T().TempDir()
+	modelsDirPath := filepath.Join(registryDirPath, "models")
+	entitiesDirPath := filepath.Join(registryDirPath, "entities")
+	suite.Nil(os.MkdirAll(modelsDirPath, 0755))
+	suite.Nil(os.MkdirAll(entitiesDirPath, 0755))
+	getModelContents := func(modelName string) string {
+		return "name: " + modelName + "\n" +
+			"fields:\n" +
+			"  ID:\n" +
+			"    type: AutoIncrement\n" +
+			"  Name:\n" +
+			"    type: String\n" +
+			"identifiers:\n" +
+			"  primary: ID\n"
+	}
+	// Entities mixing the fields of two models compile, but can't be mapped from a single model
+	getEntityContents := func(entityName string, otherModelName string) string {
+		return "name: " + entityName + "\n" +
+			"fields:\n" +
+			"  ID:\n" +
+			"    type: " + entityName + ".ID\n" +
+			"  OtherName:\n" +
+			"    type: " + otherModelName + ".Name\n" +
+			"identifiers:\n" +
+			"  primary: ID\n"
+	}
+	suite.Nil(os.WriteFile(filepath.Join(modelsDirPath, "person.mod"), []byte(getModelContents("Person")), 0644))
+	suite.Nil(os.WriteFile(filepath.Join(modelsDirPath, "company.mod"), []byte(getModelContents("Company")), 0644))
+	suite.Nil(os.WriteFile(filepath.Join(entitiesDirPath, "person.ent"), []byte(getEntityContents("Person", "Company")), 0644))
+	suite.Nil(os.WriteFile(filepath.Join(entitiesDirPath, "company.ent"), []byte(getEntityContents("Company", "Person")), 0644))
+
+	outputFS := &gofile.MemFS{}
+	config := suite.getCompileConfig("", outputFS, withRegistry(registryDirPath), func(config *compile.MorpheCompileConfig) {
+		config.MorpheEntitiesConfig.ModelMappers = true
+		config.AggregateErrors = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	var compileErrs compile.CompileErrors
+	suite.ErrorAs(compileErr, &compileErrs)
+	suite.Len(compileErrs, 2)
+	suite.Equal(compile.CompileErrorKindEntity, compileErrs[0].Kind)
+	suite.Equal("Company", compileErrs[0].Definition)
+	suite.Contains(compileErrs[0].Message, "model mapper requires a single root model")
+	suite.Equal(compile.CompileErrorKindEntity, compileErrs[1].Kind)
+	suite.Equal("Person", compileErrs[1].Definition)
+	suite.Equal(filepath.Join(entitiesDirPath, "person.ent"), compileErrs[1].Position.FilePath)
+	suite.Empty(outputFS.Files())
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_FieldOrderDeclaration() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, func(config *compile.MorpheCompileConfig) {
+		config.MorpheModelsConfig.FieldOrder = cfg.FieldOrderDeclaration
+		config.MorpheEntitiesConfig.FieldOrder = cfg.FieldOrderDeclaration
+		config.MorpheStructuresConfig.FieldOrder = cfg.FieldOrderDeclaration
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	allFiles := outputFS.Files()
+	suite.Contains(string(allFiles["models/person.go"]), "type Person struct {\n"+
+		"\tID                uint\n"+
+		"\tFirstName         string\n"+
+		"\tLastName          string\n"+
+		"\tNationality       enums.Nationality\n"+
+		"\tContactInfoID     uint\n"+
+		"\tContactInfo       *ContactInfo\n"+
+		"\tCompanyID         uint\n"+
+		"\tCompany           *Company\n"+
+		"\tWorkContactID     uint\n"+
+		"\tWorkContact       *Contact\n"+
+		"\tPersonalContactID uint\n"+
+		"\tPersonalContact   *Contact\n"+
+		"\tNoteIDs           []uint\n"+
+		"\tNotes             []Comment\n"+
+		"}\n")
+	suite.Contains(string(allFiles["entities/person.go"]), "type Person struct {\n"+
+		"\tID          uint `morphe:\"immutable\"`\n"+
+		"\tLastName    string\n"+
+		"\tNationality enums.Nationality\n"+
+		"\tEmail       string\n"+
+		"\tCompanyID   uint\n"+
+		"\tCompany     *Company\n"+
+		"}\n")
+	suite.Contains(string(allFiles["structures/address.go"]), "type Address struct {\n"+
+		"\tStreet  string\n"+
+		"\tHouseNr string\n"+
+		"\tZipCode string\n"+
+		"\tCity    string\n"+
+		"}\n")
+}
+
+func (suite *CompileTestSuite) TestMorpheToGo_DocComments() {
+	outputFS := &gofile.MemFS{}
+
+	config := suite.getCompileConfig("", outputFS, withRegistry(filepath.Join(suite.TestDirPath, "registry", "doc-comments")), func(config *compile.MorpheCompileConfig) {
+		config.MorpheModelsConfig.DocComments = true
+		config.MorpheEntitiesConfig.DocComments = true
+		config.MorpheStructuresConfig.DocComments = true
+		config.MorpheEnumsConfig.DocComments = true
+	})
+
+	compileErr := compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+
+	allFiles := outputFS.Files()
+	personContents := string(allFiles["models/person.go"])
+	suite.Contains(personContents, "// Person is a person known to the system.\ntype Person struct {\n")
+	suite.Contains(personContents, "\t// Given name of the person.\n\t// Used in salutations.\n\tFirstName   string\n\tID          uint\n\tLastName    string\n\tNationality enums.Nationality\n")
+	suite.Contains(personContents, "\t// ForOne Company ID\n\tCompanyID uint\n")
+	suite.Contains(personContents, "\t// The employer of the person.\n\t// ForOne Company\n\tCompany *Company\n")
+	suite.Contains(personContents, "\t// HasManyPoly Comment IDs\n\tNoteIDs []uint\n")
+	suite.Contains(personContents, "\t// HasManyPoly Comment\n\tNotes []Comment\n")
+	suite.Contains(string(allFiles["models/person_id_name.go"]), "// PersonIDName is the name identifier of Person.\ntype PersonIDName struct {\n")
+	suite.Contains(string(allFiles["models/company.go"]), "// Company is an organization employing people.\ntype Company struct {\n")
+	suite.Contains(string(allFiles["models/comment.go"]), "// Comment is generated from the Comment model.\ntype Comment struct {\n")
+	suite.Contains(string(allFiles["models/comment.go"]), "\t// ForOnePoly Company, Person type\n\tCommentableType string\n")
+
+	entityContents := string(allFiles["entities/person.go"])
+	suite.Contains(entityContents, "// Person is generated from the Person entity.\ntype Person struct {\n")
+	suite.NotContains(entityContents, "// Person.ID")
+
+	addressContents := string(allFiles["structures/address.go"])
+	suite.Contains(addressContents, "// Address is generated from the Address structure.\ntype Address struct {\n")
+	suite.Contains(addressContents, "\t// City or town of the address.\n\tCity   string\n\tStreet string\n")
+
+	enumContents := string(allFiles["enums/nationality.go"])
+	suite.Contains(enumContents, "// Nationalities of people.\ntype Nationality string\n")
+	suite.Contains(enumContents, "\t// NationalityDe is the Nationality value \"German\".\n\tNationalityDe Nationality = \"German\"\n")
+
+	config.MorpheModelsConfig.DocComments = false
+
+	compileErr = compile.MorpheToGo(config)
+
+	suite.NoError(compileErr)
+	suite.NotContains(string(outputFS.Files()["models/person.go"]), "// Person is a person known to the system.")
+	suite.NotContains(string(outputFS.Files()["models/person.go"]), "// ForOne Company")
+	suite.Contains(string(outputFS.Files()["entities/person.go"]), "// ForOne Company")
+}
+
+// runGeneratedTests runs test files of testdata/generated-tests (ie. "support/date_test.go") against all generated files,
+// in a module named "generated". The package paths must be configured within the module (ie. "generated/support").
+func (suite *CompileTestSuite) runGeneratedTests(outputFS *gofile.MemFS, allTestFilePaths ...string) {
+	allModuleFiles := outputFS.Files()
+	for _, testFilePath := range allTestFilePaths {
+		testContents, readErr := os.ReadFile(filepath.Join(suite.TestDirPath, "generated-tests", filepath.FromSlash(testFilePath)))
+		suite.Require().NoError(readErr)
+		allModuleFiles[testFilePath] = testContents
+	}
+
+	testOutput, testErr := testutils.RunGoTests(suite.T(), allModuleFiles)
+
+	suite.NoError(testErr, testOutput)
+}
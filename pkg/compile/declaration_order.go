@@ -0,0 +1,132 @@
+package compile
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"slices"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/morphe-go/pkg/registry"
+	rcfg "github.com/kalo-build/morphe-go/pkg/registry/cfg"
+	"github.com/kalo-build/morphe-go/pkg/yamlfile"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+// declarationOrderSource keeps the mapping nodes of a YAML definition, since the registry definitions hold maps without order.
+type declarationOrderSource struct {
+	Name        string     `yaml:"name"`
+	Identifiers yaml3.Node `yaml:"identifiers"`
+	Fields      yaml3.Node `yaml:"fields"`
+	Related     yaml3.Node `yaml:"related"`
+}
+
+// usesDeclarationOrder returns true if any struct section is compiled in declaration order.
+func usesDeclarationOrder(config cfg.MorpheConfig) bool {
+	return config.MorpheModelsConfig.FieldOrder == cfg.FieldOrderDeclaration ||
+		config.MorpheEntitiesConfig.FieldOrder == cfg.FieldOrderDeclaration ||
+		config.MorpheStructuresConfig.FieldOrder == cfg.FieldOrderDeclaration
+}
+
+// LoadDeclarationOrders reads the declaration order of all models, entities and structures from the YAML registry directories.
+func LoadDeclarationOrders(config rcfg.MorpheLoadRegistryConfig) (cfg.DeclarationOrders, error) {
+	allModelOrders, modelsErr := loadDeclarationOrdersFromDirectory(config.RegistryModelsDirPath, registry.ModelFileSuffix)
+	if modelsErr != nil {
+		return cfg.DeclarationOrders{}, modelsErr
+	}
+	allEntityOrders, entitiesErr := loadDeclarationOrdersFromDirectory(config.RegistryEntitiesDirPath, registry.EntityFileSuffix)
+	if entitiesErr != nil {
+		return cfg.DeclarationOrders{}, entitiesErr
+	}
+	allStructureOrders, structuresErr := loadDeclarationOrdersFromDirectory(config.RegistryStructuresDirPath, registry.StructureFileSuffix)
+	if structuresErr != nil {
+		return cfg.DeclarationOrders{}, structuresErr
+	}
+
+	return cfg.DeclarationOrders{
+		Models:     allModelOrders,
+		Entities:   allEntityOrders,
+		Structures: allStructureOrders,
+	}, nil
+}
+
+func loadDeclarationOrdersFromDirectory(dirPath string, fileSuffix string) (map[string]cfg.DeclarationOrder, error) {
+	allSources, unmarshalErr := unmarshalAllRegistrySources[declarationOrderSource](dirPath, fileSuffix)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	allOrders := map[string]cfg.DeclarationOrder{}
+	for _, source := range allSources {
+		allOrders[source.Name] = cfg.DeclarationOrder{
+			Identifiers: getMappingNodeKeys(source.Identifiers),
+			Fields:      getMappingNodeKeys(source.Fields),
+			Related:     getMappingNodeKeys(source.Related),
+		}
+	}
+	return allOrders, nil
+}
+
+// unmarshalAllRegistrySources reads all YAML definitions with the file suffix from a registry directory.
+// Missing directories are skipped, like the registry does.
+func unmarshalAllRegistrySources[TSource any](dirPath string, fileSuffix string) (map[string]TSource, error) {
+	if _, statErr := os.Stat(dirPath); errors.Is(statErr, fs.ErrNotExist) {
+		return map[string]TSource{}, nil
+	}
+	return yamlfile.UnmarshalAllYAMLFiles[TSource](dirPath, fileSuffix)
+}
+
+func getMappingNodeKeys(node yaml3.Node) []string {
+	if node.Kind != yaml3.MappingNode {
+		return nil
+	}
+	allKeys := []string{}
+	// Mapping node contents alternate between keys and values
+	for keyIdx := 0; keyIdx < len(node.Content); keyIdx += 2 {
+		allKeys = append(allKeys, node.Content[keyIdx].Value)
+	}
+	return allKeys
+}
+
+// getOrderedFieldNames returns the names of the direct fields in the configured order. The declaration order starts with
+// the primary identifier fields, followed by the fields of the other identifiers and the remaining fields as declared.
+func getOrderedFieldNames[TField any](fieldOrder cfg.FieldOrder, declarationOrder cfg.DeclarationOrder, allFields map[string]TField, allIdentifiers map[string]Identifier) []string {
+	if fieldOrder != cfg.FieldOrderDeclaration {
+		return core.MapKeysSorted(allFields)
+	}
+
+	declaredNames := []string{}
+	if primaryIdentifier, hasPrimary := allIdentifiers["primary"]; hasPrimary {
+		declaredNames = append(declaredNames, primaryIdentifier.GetFields()...)
+	}
+	for _, identifierName := range getDeclaredNames(declarationOrder.Identifiers, allIdentifiers) {
+		declaredNames = append(declaredNames, allIdentifiers[identifierName].GetFields()...)
+	}
+	declaredNames = append(declaredNames, declarationOrder.Fields...)
+	return getDeclaredNames(declaredNames, allFields)
+}
+
+// getOrderedRelationNames returns the names of the relations in the configured order.
+func getOrderedRelationNames[TRelation any](fieldOrder cfg.FieldOrder, declarationOrder cfg.DeclarationOrder, allRelations map[string]TRelation) []string {
+	if fieldOrder != cfg.FieldOrderDeclaration {
+		return core.MapKeysSorted(allRelations)
+	}
+	return getDeclaredNames(declarationOrder.Related, allRelations)
+}
+
+// getDeclaredNames orders the keys by their first declared name. Keys without a declaration follow in sorted order.
+func getDeclaredNames[TValue any](declaredNames []string, allValues map[string]TValue) []string {
+	orderedNames := []string{}
+	for _, name := range declaredNames {
+		if _, isKey := allValues[name]; isKey && !slices.Contains(orderedNames, name) {
+			orderedNames = append(orderedNames, name)
+		}
+	}
+	for _, name := range core.MapKeysSorted(allValues) {
+		if !slices.Contains(orderedNames, name) {
+			orderedNames = append(orderedNames, name)
+		}
+	}
+	return orderedNames
+}
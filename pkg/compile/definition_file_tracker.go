@@ -0,0 +1,110 @@
+package compile
+
+import (
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+)
+
+// definitionFileTracker writes (or checks) the definition files of a writer and keeps track of them, for the check
+// result, the stale file cleanup and the generated files report. It is embedded by all definition file writers.
+type definitionFileTracker struct {
+	// writtenFileNames tracks all files written (or checked) since the last stale file cleanup
+	writtenFileNames map[string]any
+	checkResult      gofile.CheckResult
+
+	// generatedFiles tracks all files written (or checked) since the last GetGeneratedFiles call (file name -> file)
+	generatedFiles map[string]gofile.GeneratedFile
+}
+
+// GetGeneratedFiles returns the files written (or checked) since the last call, sorted by path.
+func (t *definitionFileTracker) GetGeneratedFiles() []gofile.GeneratedFile {
+	allFiles := []gofile.GeneratedFile{}
+	for _, generatedFile := range t.generatedFiles {
+		allFiles = append(allFiles, generatedFile)
+	}
+	gofile.SortGeneratedFiles(allFiles)
+	t.generatedFiles = nil
+	return allFiles
+}
+
+// ResetTrackedFiles forgets all written (or checked) files, the check result and the generated files.
+func (t *definitionFileTracker) ResetTrackedFiles() {
+	t.writtenFileNames = nil
+	t.checkResult = gofile.CheckResult{}
+	t.generatedFiles = nil
+}
+
+// writeDefinitionFile formats and writes a definition file, or compares it with the existing file in check mode.
+func (t *definitionFileTracker) writeDefinitionFile(outputFS gofile.OutputFS, dirPath string, checkOnly bool, definitionName string, fileContents string) ([]byte, error) {
+	if checkOnly {
+		return t.checkDefinitionFile(outputFS, dirPath, definitionName, fileContents)
+	}
+
+	formattedContents, writeErr := gofile.WriteGoDefinitionFile(getOutputFS(outputFS), dirPath, definitionName, fileContents)
+	if writeErr != nil {
+		return nil, writeErr
+	}
+	t.addWrittenFile(dirPath, definitionName, formattedContents)
+	return formattedContents, nil
+}
+
+func (t *definitionFileTracker) checkDefinitionFile(outputFS gofile.OutputFS, dirPath string, definitionName string, fileContents string) ([]byte, error) {
+	formattedContents, formatErr := gofile.FormatGoDefinitionFile(fileContents)
+	if formatErr != nil {
+		return nil, formatErr
+	}
+
+	checkResult, checkErr := gofile.CheckGoDefinitionFile(getOutputFS(outputFS), dirPath, definitionName, formattedContents)
+	if checkErr != nil {
+		return nil, checkErr
+	}
+	t.checkResult.Merge(checkResult)
+	t.addWrittenFile(dirPath, definitionName, formattedContents)
+	return formattedContents, nil
+}
+
+// getCheckResult returns all differences found in check mode since the last call, including generated files that were not rendered again.
+func (t *definitionFileTracker) getCheckResult(outputFS gofile.OutputFS, dirPath string) (gofile.CheckResult, error) {
+	extraFilePaths, extraErr := gofile.FindStaleGoDefinitionFiles(getOutputFS(outputFS), dirPath, t.writtenFileNames)
+	if extraErr != nil {
+		return gofile.CheckResult{}, extraErr
+	}
+
+	checkResult := t.checkResult
+	checkResult.Merge(gofile.CheckResult{
+		Extra: extraFilePaths,
+	})
+
+	t.checkResult = gofile.CheckResult{}
+	t.writtenFileNames = nil
+	return checkResult, nil
+}
+
+// removeStaleFiles deletes all generated files in the directory that were not written since the last cleanup.
+func (t *definitionFileTracker) removeStaleFiles(outputFS gofile.OutputFS, dirPath string) ([]string, error) {
+	removedFilePaths, removeErr := gofile.RemoveStaleGoDefinitionFiles(getOutputFS(outputFS), dirPath, t.writtenFileNames)
+	if removeErr != nil {
+		return nil, removeErr
+	}
+	t.writtenFileNames = nil
+	return removedFilePaths, nil
+}
+
+func (t *definitionFileTracker) addWrittenFile(dirPath string, definitionName string, formattedContents []byte) {
+	fileName := gofile.GetDefinitionFileName(definitionName)
+	if t.writtenFileNames == nil {
+		t.writtenFileNames = map[string]any{}
+	}
+	t.writtenFileNames[fileName] = nil
+	if t.generatedFiles == nil {
+		t.generatedFiles = map[string]gofile.GeneratedFile{}
+	}
+	t.generatedFiles[fileName] = gofile.NewGeneratedFile(dirPath, definitionName, formattedContents)
+}
+
+// getOutputFS returns the output filesystem of a writer, the local filesystem if none is set.
+func getOutputFS(outputFS gofile.OutputFS) gofile.OutputFS {
+	if outputFS == nil {
+		return gofile.OSFS{}
+	}
+	return outputFS
+}
@@ -0,0 +1,243 @@
+package compile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+)
+
+// fieldChangeDefinitionName is the definition (and file) name of the FieldChange type returned by the generated Diff methods.
+const fieldChangeDefinitionName = "FieldChange"
+
+// fieldChangeSource declares the changes returned by the generated Diff methods and the comparison helpers, see getFieldChangeFileContents.
+const fieldChangeSource = `import "reflect"
+
+// FieldChange is a field that differs between two structs, as returned by the generated Diff methods.
+// Optional fields hold nil or the value they point to.
+type FieldChange struct {
+	Field string
+	Old   any
+	New   any
+}
+
+// equalPointer compares the values of two optional fields.
+func equalPointer[T comparable](a *T, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// equalPointerFunc compares the values of two optional fields with an equal function, ie. time.Time.Equal.
+func equalPointerFunc[T any](a *T, b *T, equal func(T, T) bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equal(*a, *b)
+}
+
+// equalValue compares two values of an overridden type with their Equal method (ie. decimal.Decimal.Equal), or deeply
+// if they have none, since overridden types are not necessarily comparable.
+func equalValue[T any](a T, b T) bool {
+	if equaler, isEqualer := any(a).(interface{ Equal(T) bool }); isEqualer {
+		return equaler.Equal(b)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// equalSlice compares two slices element by element, ie. related IDs. Nil and empty slices are equal.
+func equalSlice[T comparable](a []T, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalSliceFunc compares two slices element by element with an equal function. Nil and empty slices are equal.
+func equalSliceFunc[T any](a []T, b []T, equal func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if !equal(a[idx], b[idx]) {
+			return false
+		}
+	}
+	return true
+}
+
+// pointerValue returns the value of an optional field, or nil if it is not set.
+func pointerValue[T any](value *T) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+`
+
+func getFieldChangeFileContents(structPackage godef.Package) string {
+	return fmt.Sprintf("%s\n\npackage %s\n\n%s", gofile.GeneratedFileHeader, structPackage.Name, fieldChangeSource)
+}
+
+// diffField is a field compared by the generated Equal and Diff methods.
+type diffField struct {
+	Name string
+	// EqualExpr compares the field of the receiver and "other", ie. "m.Name == other.Name"
+	EqualExpr string
+	// ChangedExpr is the negated EqualExpr, ie. "m.Name != other.Name"
+	ChangedExpr string
+	// ValueFormat formats the value stored in the FieldChange, ie. "pointerValue(%s)"
+	ValueFormat string
+}
+
+// getDiffMethods builds the Equal and Diff methods of a model or entity. Relations are compared by their related ID fields,
+// the related structs and the marker interfaces of typed polymorphic relations are not traversed.
+func getDiffMethods(structPackage godef.Package, typeOverrides cfg.MorpheTypeOverridesConfig, receiverName string, structName string, allFields []godef.StructField) []godef.StructMethod {
+	receiverType := godef.GoTypeStruct{
+		PackagePath: structPackage.Path,
+		Name:        structName,
+	}
+	// Parameter types are rendered with their package name, the struct is declared in the current package
+	otherType := godef.GoTypeStruct{
+		Name: structName,
+	}
+
+	allDiffFields := []diffField{}
+	for _, field := range allFields {
+		comparedField, isCompared := getDiffField(structPackage, typeOverrides, receiverName, field)
+		if isCompared {
+			allDiffFields = append(allDiffFields, comparedField)
+		}
+	}
+
+	equalExprs := []string{}
+	diffLines := []string{
+		"\tchanges := []FieldChange{}",
+	}
+	for _, field := range allDiffFields {
+		equalExprs = append(equalExprs, field.EqualExpr)
+		diffLines = append(diffLines,
+			fmt.Sprintf("\tif %s {", field.ChangedExpr),
+			fmt.Sprintf(
+				"\t\tchanges = append(changes, FieldChange{Field: %q, Old: %s, New: %s})",
+				field.Name,
+				fmt.Sprintf(field.ValueFormat, receiverName+"."+field.Name),
+				fmt.Sprintf(field.ValueFormat, "other."+field.Name),
+			),
+			"\t}",
+		)
+	}
+	diffLines = append(diffLines, "\treturn changes")
+
+	equalLine := "\treturn true"
+	if len(equalExprs) > 0 {
+		equalLine = "\treturn " + strings.Join(equalExprs, " &&\n\t\t")
+	}
+
+	return []godef.StructMethod{
+		{
+			ReceiverName: receiverName,
+			ReceiverType: receiverType,
+			Name:         "Equal",
+			Parameters: map[string]godef.GoType{
+				"other": otherType,
+			},
+			ReturnTypes: []godef.GoType{godef.GoTypeBool},
+			BodyLines:   []string{equalLine},
+		},
+		{
+			ReceiverName: receiverName,
+			ReceiverType: receiverType,
+			Name:         "Diff",
+			Parameters: map[string]godef.GoType{
+				"other": otherType,
+			},
+			ReturnTypes: []godef.GoType{
+				godef.GoTypeArray{
+					IsSlice:   true,
+					ValueType: godef.GoTypeStruct{Name: fieldChangeDefinitionName},
+				},
+			},
+			BodyLines: diffLines,
+		},
+	}
+}
+
+// getDiffField returns how a field is compared, time values are compared with time.Time.Equal so that the location
+// and monotonic clock reading are ignored. Overridden types may not be comparable, they are compared with equalValue.
+func getDiffField(structPackage godef.Package, typeOverrides cfg.MorpheTypeOverridesConfig, receiverName string, field godef.StructField) (diffField, bool) {
+	fieldRef := receiverName + "." + field.Name
+	otherRef := "other." + field.Name
+	switch fieldType := field.Type.(type) {
+	case godef.GoTypeInterface:
+		return diffField{}, false
+	case godef.GoTypePointer:
+		if _, isLocalStruct := getLocalStructName(structPackage, fieldType.ValueType); isLocalStruct {
+			return diffField{}, false
+		}
+		equalExpr := fmt.Sprintf("equalPointer(%s, %s)", fieldRef, otherRef)
+		if isTimeType(fieldType.ValueType) {
+			equalExpr = fmt.Sprintf("equalPointerFunc(%s, %s, time.Time.Equal)", fieldRef, otherRef)
+		} else if typeOverrides.IsOverrideType(fieldType.ValueType) {
+			equalExpr = fmt.Sprintf("equalPointerFunc(%s, %s, equalValue[%s])", fieldRef, otherRef, fieldType.ValueType.GetSyntax())
+		}
+		return diffField{
+			Name:        field.Name,
+			EqualExpr:   equalExpr,
+			ChangedExpr: "!" + equalExpr,
+			ValueFormat: "pointerValue(%s)",
+		}, true
+	case godef.GoTypeArray:
+		if _, isLocalStruct := getLocalStructName(structPackage, fieldType.ValueType); isLocalStruct {
+			return diffField{}, false
+		}
+		equalExpr := fmt.Sprintf("equalSlice(%s, %s)", fieldRef, otherRef)
+		if typeOverrides.IsOverrideType(fieldType.ValueType) {
+			equalExpr = fmt.Sprintf("equalSliceFunc(%s, %s, equalValue[%s])", fieldRef, otherRef, fieldType.ValueType.GetSyntax())
+		}
+		return diffField{
+			Name:        field.Name,
+			EqualExpr:   equalExpr,
+			ChangedExpr: "!" + equalExpr,
+			ValueFormat: "%s",
+		}, true
+	}
+
+	if isTimeType(field.Type) {
+		equalExpr := fmt.Sprintf("%s.Equal(%s)", fieldRef, otherRef)
+		return diffField{
+			Name:        field.Name,
+			EqualExpr:   equalExpr,
+			ChangedExpr: "!" + equalExpr,
+			ValueFormat: "%s",
+		}, true
+	}
+	if typeOverrides.IsOverrideType(field.Type) {
+		equalExpr := fmt.Sprintf("equalValue(%s, %s)", fieldRef, otherRef)
+		return diffField{
+			Name:        field.Name,
+			EqualExpr:   equalExpr,
+			ChangedExpr: "!" + equalExpr,
+			ValueFormat: "%s",
+		}, true
+	}
+	return diffField{
+		Name:        field.Name,
+		EqualExpr:   fmt.Sprintf("%s == %s", fieldRef, otherRef),
+		ChangedExpr: fmt.Sprintf("%s != %s", fieldRef, otherRef),
+		ValueFormat: "%s",
+	}, true
+}
+
+func isTimeType(goType godef.GoType) bool {
+	structType, isStructType := goType.(godef.GoTypeStruct)
+	return isStructType && structType.PackagePath == godef.GoTypeTime.PackagePath && structType.Name == godef.GoTypeTime.Name
+}
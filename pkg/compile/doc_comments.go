@@ -0,0 +1,327 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/go-util/inflect"
+	"github.com/kalo-build/go-util/strcase"
+	"github.com/kalo-build/morphe-go/pkg/registry"
+	rcfg "github.com/kalo-build/morphe-go/pkg/registry/cfg"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/morphe-go/pkg/yamlops"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/write"
+)
+
+// DefinitionDescription holds the "description" keys of a YAML definition, its fields and its relations.
+type DefinitionDescription struct {
+	Definition string
+	Fields     map[string]string
+	Related    map[string]string
+}
+
+// Descriptions holds the descriptions of all definitions by name.
+type Descriptions struct {
+	Models     map[string]DefinitionDescription
+	Entities   map[string]DefinitionDescription
+	Structures map[string]DefinitionDescription
+	Enums      map[string]DefinitionDescription
+}
+
+// descriptionSource reads the "description" keys of a YAML definition, which the registry definitions don't hold.
+type descriptionSource struct {
+	Name        string                            `yaml:"name"`
+	Description string                            `yaml:"description"`
+	Fields      map[string]descriptionEntrySource `yaml:"fields"`
+	Related     map[string]descriptionEntrySource `yaml:"related"`
+}
+
+type descriptionEntrySource struct {
+	Description string `yaml:"description"`
+}
+
+// usesDocComments returns true if any section renders doc comments.
+func usesDocComments(config cfg.MorpheConfig) bool {
+	return config.MorpheModelsConfig.DocComments ||
+		config.MorpheEntitiesConfig.DocComments ||
+		config.MorpheStructuresConfig.DocComments ||
+		config.MorpheEnumsConfig.DocComments
+}
+
+// LoadDescriptions reads the descriptions of all definitions from the YAML registry directories.
+func LoadDescriptions(config rcfg.MorpheLoadRegistryConfig) (Descriptions, error) {
+	allModelDescriptions, modelsErr := loadDescriptionsFromDirectory(config.RegistryModelsDirPath, registry.ModelFileSuffix)
+	if modelsErr != nil {
+		return Descriptions{}, modelsErr
+	}
+	allEntityDescriptions, entitiesErr := loadDescriptionsFromDirectory(config.RegistryEntitiesDirPath, registry.EntityFileSuffix)
+	if entitiesErr != nil {
+		return Descriptions{}, entitiesErr
+	}
+	allStructureDescriptions, structuresErr := loadDescriptionsFromDirectory(config.RegistryStructuresDirPath, registry.StructureFileSuffix)
+	if structuresErr != nil {
+		return Descriptions{}, structuresErr
+	}
+	allEnumDescriptions, enumsErr := loadDescriptionsFromDirectory(config.RegistryEnumsDirPath, registry.EnumFileSuffix)
+	if enumsErr != nil {
+		return Descriptions{}, enumsErr
+	}
+
+	return Descriptions{
+		Models:     allModelDescriptions,
+		Entities:   allEntityDescriptions,
+		Structures: allStructureDescriptions,
+		Enums:      allEnumDescriptions,
+	}, nil
+}
+
+func loadDescriptionsFromDirectory(dirPath string, fileSuffix string) (map[string]DefinitionDescription, error) {
+	allSources, unmarshalErr := unmarshalAllRegistrySources[descriptionSource](dirPath, fileSuffix)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	allDescriptions := map[string]DefinitionDescription{}
+	for _, source := range allSources {
+		description := DefinitionDescription{
+			Definition: source.Description,
+			Fields:     map[string]string{},
+			Related:    map[string]string{},
+		}
+		for fieldName, fieldSource := range source.Fields {
+			description.Fields[fieldName] = fieldSource.Description
+		}
+		for relationName, relationSource := range source.Related {
+			description.Related[relationName] = relationSource.Description
+		}
+		allDescriptions[source.Name] = description
+	}
+	return allDescriptions, nil
+}
+
+// getAllModelStructDocs returns the docs of all model structs and their identifier structs.
+func getAllModelStructDocs(config cfg.MorpheConfig, r *registry.Registry, allDescriptions map[string]DefinitionDescription) map[string]write.StructDoc {
+	allStructDocs := map[string]write.StructDoc{}
+	for modelName, model := range r.GetAllModels() {
+		description := allDescriptions[modelName]
+		modelDoc := write.StructDoc{
+			Lines:      getTypeDocLines(modelName, description.Definition, fmt.Sprintf("%s is generated from the %s model.", modelName, modelName)),
+			FieldLines: map[string][]string{},
+		}
+		for fieldName := range model.Fields {
+			modelDoc.FieldLines[fieldName] = getDescriptionLines(description.Fields[fieldName], "")
+		}
+		for relationName, relationDef := range model.Related {
+			relationLines := getRelationDocLines(relationName, relationDef, description.Related[relationName], config.MorpheModelsConfig.TypedPolyRelations, func(targetName string) string {
+				targetModel, targetErr := r.GetModel(targetName)
+				if targetErr != nil {
+					return "ID"
+				}
+				targetPrimaryIDFieldName, _ := yamlops.GetModelPrimaryIdentifierFieldName(targetModel)
+				return targetPrimaryIDFieldName
+			})
+			for fieldName, fieldLines := range relationLines {
+				modelDoc.FieldLines[fieldName] = fieldLines
+			}
+		}
+		allStructDocs[modelName] = modelDoc
+		addIdentifierStructDocs(allStructDocs, modelName, wrapModelIdentifiers(model.Identifiers), modelDoc)
+	}
+	return allStructDocs
+}
+
+// getAllEntityStructDocs returns the docs of all entity structs and their identifier structs.
+func getAllEntityStructDocs(r *registry.Registry, allDescriptions map[string]DefinitionDescription) map[string]write.StructDoc {
+	allStructDocs := map[string]write.StructDoc{}
+	for entityName, entity := range r.GetAllEntities() {
+		description := allDescriptions[entityName]
+		entityDoc := write.StructDoc{
+			Lines:      getTypeDocLines(entityName, description.Definition, fmt.Sprintf("%s is generated from the %s entity.", entityName, entityName)),
+			FieldLines: map[string][]string{},
+		}
+		for fieldName := range entity.Fields {
+			entityDoc.FieldLines[fieldName] = getDescriptionLines(description.Fields[fieldName], "")
+		}
+		for relationName, relationDef := range entity.Related {
+			// Related entity IDs are always named after the relation, see getRelatedGoFieldForEntityPrimaryID
+			relationLines := getRelationDocLines(relationName, yaml.ModelRelation(relationDef), description.Related[relationName], false, func(string) string {
+				return "ID"
+			})
+			for fieldName, fieldLines := range relationLines {
+				entityDoc.FieldLines[fieldName] = fieldLines
+			}
+		}
+		allStructDocs[entityName] = entityDoc
+		addIdentifierStructDocs(allStructDocs, entityName, wrapEntityIdentifiers(entity.Identifiers), entityDoc)
+	}
+	return allStructDocs
+}
+
+// getAllStructureStructDocs returns the docs of all structure structs.
+func getAllStructureStructDocs(r *registry.Registry, allDescriptions map[string]DefinitionDescription) map[string]write.StructDoc {
+	allStructDocs := map[string]write.StructDoc{}
+	for structureName, structure := range r.GetAllStructures() {
+		description := allDescriptions[structureName]
+		structureDoc := write.StructDoc{
+			Lines:      getTypeDocLines(structureName, description.Definition, fmt.Sprintf("%s is generated from the %s structure.", structureName, structureName)),
+			FieldLines: map[string][]string{},
+		}
+		for fieldName := range structure.Fields {
+			structureDoc.FieldLines[fieldName] = getDescriptionLines(description.Fields[fieldName], "")
+		}
+		allStructDocs[structureName] = structureDoc
+	}
+	return allStructDocs
+}
+
+// getAllEnumDocs returns the docs of all enums, the entries are documented with their value.
+func getAllEnumDocs(r *registry.Registry, allDescriptions map[string]DefinitionDescription) map[string]write.EnumDoc {
+	allEnumDocs := map[string]write.EnumDoc{}
+	for enumName, enum := range r.GetAllEnums() {
+		enumDoc := write.EnumDoc{
+			Lines:      getTypeDocLines(enumName, allDescriptions[enumName].Definition, fmt.Sprintf("%s is generated from the %s enum.", enumName, enumName)),
+			EntryLines: map[string][]string{},
+		}
+		for entryName, entryValue := range enum.Entries {
+			// Entry names match getGoEntriesForMorpheEnum
+			goEntryName := enumName + entryName
+			enumDoc.EntryLines[goEntryName] = []string{fmt.Sprintf("%s is the %s value %s.", strcase.ToPascalCase(goEntryName), enumName, getEnumValueDoc(entryValue))}
+		}
+		allEnumDocs[enumName] = enumDoc
+	}
+	return allEnumDocs
+}
+
+// addIdentifierStructDocs documents the identifier structs of a model or entity, their fields share the docs of the parent fields.
+func addIdentifierStructDocs(allStructDocs map[string]write.StructDoc, parentName string, allIdentifiers map[string]Identifier, parentDoc write.StructDoc) {
+	for _, identifierName := range core.MapKeysSorted(allIdentifiers) {
+		identifierStructName := getIdentifierStructName(parentName, identifierName)
+		identifierDoc := write.StructDoc{
+			Lines:      []string{fmt.Sprintf("%s is the %s identifier of %s.", identifierStructName, identifierName, parentName)},
+			FieldLines: map[string][]string{},
+		}
+		for _, fieldName := range allIdentifiers[identifierName].GetFields() {
+			identifierDoc.FieldLines[fieldName] = parentDoc.FieldLines[fieldName]
+		}
+		allStructDocs[identifierStructName] = identifierDoc
+	}
+}
+
+// getRelationDocLines returns the docs of all fields compiled for a relation, which all note the relation type and target
+// (ie. "HasMany Person"). The description is put on the field holding the related struct(s), or the type field of polymorphic For* relations.
+func getRelationDocLines(relationName string, relationDef yaml.ModelRelation, description string, typedPoly bool, getTargetIDFieldName func(targetName string) string) map[string][]string {
+	if isModelPolyForRelation(relationDef) {
+		relationNote := fmt.Sprintf("%s %s", relationDef.Type, strings.Join(getSortedPolyTargetNames(relationDef), ", "))
+		allFieldLines := map[string][]string{
+			relationName + "Type": getDescriptionLines(description, relationNote+" type"),
+			relationName + "ID":   {relationNote + " ID"},
+		}
+		if typedPoly {
+			allFieldLines[relationName] = []string{relationNote}
+		}
+		return allFieldLines
+	}
+
+	// HasMany path aliases (ie. "Person.WorkProject") target the model before the dot
+	targetName := strings.Split(yamlops.GetRelationTargetName(relationName, relationDef.Aliased), ".")[0]
+	relationNote := fmt.Sprintf("%s %s", relationDef.Type, targetName)
+	idFieldName := relationName + getTargetIDFieldName(targetName)
+	relatedFieldName := relationName
+	if yamlops.IsRelationMany(relationDef.Type) {
+		return map[string][]string{
+			idFieldName + "s":                {relationNote + " IDs"},
+			inflect.Plural(relatedFieldName): append(getDescriptionLines(description, ""), relationNote),
+		}
+	}
+	return map[string][]string{
+		idFieldName:      {relationNote + " ID"},
+		relatedFieldName: append(getDescriptionLines(description, ""), relationNote),
+	}
+}
+
+// docArticles are the leading articles of descriptions rewritten to start with the type name.
+var docArticles = []string{"A", "An", "The"}
+
+// getTypeDocLines returns the doc of a generated type. Go doc conventions start it with the type name, so a description
+// starting with an article is rewritten, ie. "A person known to the system." becomes "Person is a person known to the
+// system.". Other descriptions are used as written.
+func getTypeDocLines(typeName string, description string, fallbackLine string) []string {
+	allLines := getDescriptionLines(description, fallbackLine)
+	for _, article := range docArticles {
+		if rest, hasArticle := strings.CutPrefix(allLines[0], article+" "); hasArticle {
+			allLines[0] = fmt.Sprintf("%s is %s %s", typeName, strings.ToLower(article), rest)
+			break
+		}
+	}
+	return allLines
+}
+
+// getDescriptionLines splits a description into doc lines, or returns the fallback line if the description is empty.
+func getDescriptionLines(description string, fallbackLine string) []string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		if fallbackLine == "" {
+			return nil
+		}
+		return []string{fallbackLine}
+	}
+
+	allLines := []string{}
+	for _, line := range strings.Split(description, "\n") {
+		allLines = append(allLines, strings.TrimSpace(line))
+	}
+	return allLines
+}
+
+func getEnumValueDoc(value any) string {
+	if stringValue, isString := value.(string); isString {
+		return fmt.Sprintf("%q", stringValue)
+	}
+	return fmt.Sprint(value)
+}
+
+// getDocCommentLines renders doc lines as comments with the given indentation.
+func getDocCommentLines(indent string, docLines []string) []string {
+	commentLines := slices.Clone(docLines)
+	for lineIdx, line := range commentLines {
+		commentLines[lineIdx] = strings.TrimRight(indent+"// "+line, " ")
+	}
+	return commentLines
+}
+
+// setAllDocComments hands the docs of all enabled sections to the writers implementing write.StructDocsSetter or
+// write.EnumDocsSetter. Disabled sections are reset, since writers can be reused.
+func setAllDocComments(config MorpheCompileConfig, r *registry.Registry, descriptions Descriptions) {
+	var allModelDocs, allEntityDocs, allStructureDocs map[string]write.StructDoc
+	var allEnumDocs map[string]write.EnumDoc
+	if config.MorpheModelsConfig.DocComments {
+		allModelDocs = getAllModelStructDocs(config.MorpheConfig, r, descriptions.Models)
+	}
+	if config.MorpheEntitiesConfig.DocComments {
+		allEntityDocs = getAllEntityStructDocs(r, descriptions.Entities)
+	}
+	if config.MorpheStructuresConfig.DocComments {
+		allStructureDocs = getAllStructureStructDocs(r, descriptions.Structures)
+	}
+	if config.MorpheEnumsConfig.DocComments {
+		allEnumDocs = getAllEnumDocs(r, descriptions.Enums)
+	}
+
+	setStructDocs(config.ModelWriter, allModelDocs)
+	setStructDocs(config.EntityWriter, allEntityDocs)
+	setStructDocs(config.StructureWriter, allStructureDocs)
+	enumDocsSetter, isEnumDocsSetter := config.EnumWriter.(write.EnumDocsSetter)
+	if isEnumDocsSetter {
+		enumDocsSetter.SetEnumDocs(allEnumDocs)
+	}
+}
+
+func setStructDocs(writer write.GoStructWriter, allStructDocs map[string]write.StructDoc) {
+	structDocsSetter, isStructDocsSetter := writer.(write.StructDocsSetter)
+	if isStructDocsSetter {
+		structDocsSetter.SetStructDocs(allStructDocs)
+	}
+}
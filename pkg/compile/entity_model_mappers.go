@@ -0,0 +1,344 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/go-util/strcase"
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/morphe-go/pkg/registry"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/morphe-go/pkg/yamlops"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+)
+
+// entityModelMapperVarName is the name of the entity built by the generated mapper functions, the model parameter is "m".
+const entityModelMapperVarName = "entity"
+
+// entityModelMapperBody collects the body lines of a generated <Entity>FromModel function.
+type entityModelMapperBody struct {
+	EntityName string
+	Lines      []string
+	Imports    []string
+	UsesErrors bool
+
+	checkedRelationPaths []string
+}
+
+// AllMorpheEntityModelMappers returns the rendered contents of the model mapper functions of all entities
+// (definition name -> contents) if model mappers are enabled, ie. "PersonFromModel" for the "Person" entity.
+func AllMorpheEntityModelMappers(config MorpheCompileConfig, r *registry.Registry) (map[string]string, error) {
+	allMapperDefs := map[string]string{}
+	if !config.MorpheEntitiesConfig.ModelMappers {
+		return allMapperDefs, nil
+	}
+	if r == nil {
+		return nil, ErrNoRegistry
+	}
+
+	allErrs := CompileErrors{}
+	allEntities := r.GetAllEntities()
+	for _, entityName := range core.MapKeysSorted(allEntities) {
+		mapperContents, mapperErr := getEntityModelMapperFileContents(config.MorpheConfig, r, allEntities[entityName])
+		if mapperErr != nil {
+			if addErr := addCompileError(config, &allErrs, CompileErrorKindEntity, entityName, mapperErr); addErr != nil {
+				return nil, addErr
+			}
+			continue
+		}
+		allMapperDefs[getEntityModelMapperName(entityName)] = mapperContents
+	}
+	if compileErrs := allErrs.Err(); compileErrs != nil {
+		return nil, compileErrs
+	}
+	return allMapperDefs, nil
+}
+
+func getEntityModelMapperName(entityName string) string {
+	return entityName + "FromModel"
+}
+
+func getEntityModelMapperFileContents(config cfg.MorpheConfig, r *registry.Registry, entity yaml.Entity) (string, error) {
+	rootModel, rootErr := getEntityRootModel(r, entity)
+	if rootErr != nil {
+		return "", rootErr
+	}
+	allEntityFields, fieldsErr := getGoFieldsForMorpheEntity(config, r, entity)
+	if fieldsErr != nil {
+		return "", fieldsErr
+	}
+	relatedIDFieldRelations := getEntityRelatedIDFieldRelations(entity)
+
+	body := &entityModelMapperBody{
+		EntityName: entity.Name,
+	}
+	for _, entityField := range allEntityFields {
+		if fieldDef, isDirectField := entity.Fields[entityField.Name]; isDirectField {
+			directErr := body.addDirectField(config, r, rootModel, entityField, fieldDef.Type)
+			if directErr != nil {
+				return "", directErr
+			}
+			continue
+		}
+		if relationName, isRelatedIDField := relatedIDFieldRelations[entityField.Name]; isRelatedIDField {
+			relatedErr := body.addRelatedIDField(config, r, rootModel, entityField, relationName, entity.Related[relationName])
+			if relatedErr != nil {
+				return "", relatedErr
+			}
+		}
+	}
+
+	entitiesPackage := config.MorpheEntitiesConfig.Package
+	modelsPackage := config.MorpheModelsConfig.Package
+	modelTypeName := rootModel.Name
+	allImports := []string{}
+	if modelsPackage.Path != entitiesPackage.Path {
+		modelTypeName = modelsPackage.Name + "." + rootModel.Name
+		allImports = append(allImports, modelsPackage.Path)
+	}
+	if body.UsesErrors {
+		allImports = append(allImports, "errors")
+	}
+	for _, importPath := range body.Imports {
+		if importPath != entitiesPackage.Path && !slices.Contains(allImports, importPath) {
+			allImports = append(allImports, importPath)
+		}
+	}
+	sort.Strings(allImports)
+
+	mapperName := getEntityModelMapperName(entity.Name)
+	allLines := []string{
+		gofile.GeneratedFileHeader,
+		"",
+		fmt.Sprintf("package %s", entitiesPackage.Name),
+		"",
+	}
+	if len(allImports) > 0 {
+		allLines = append(allLines, "import (")
+		allLines = append(allLines, getGroupedImportLines(allImports)...)
+		allLines = append(allLines, ")", "")
+	}
+	allLines = append(allLines,
+		fmt.Sprintf("// %s maps the %s model to the %s entity. It returns an error if a relation on the field paths", mapperName, rootModel.Name, entity.Name),
+		"// of the entity is not loaded. Related entities are not mapped.",
+		fmt.Sprintf("func %s(m %s) (%s, error) {", mapperName, modelTypeName, entity.Name),
+		fmt.Sprintf("\t%s := %s{}", entityModelMapperVarName, entity.Name),
+	)
+	allLines = append(allLines, body.Lines...)
+	allLines = append(allLines,
+		fmt.Sprintf("\treturn %s, nil", entityModelMapperVarName),
+		"}",
+		"",
+	)
+	return strings.Join(allLines, "\n"), nil
+}
+
+// getGroupedImportLines renders the sorted import paths, standard library packages first.
+func getGroupedImportLines(allImports []string) []string {
+	stdImportLines := []string{}
+	otherImportLines := []string{}
+	for _, importPath := range allImports {
+		importLine := fmt.Sprintf("\t%q", importPath)
+		if !strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			stdImportLines = append(stdImportLines, importLine)
+			continue
+		}
+		otherImportLines = append(otherImportLines, importLine)
+	}
+	if len(stdImportLines) > 0 && len(otherImportLines) > 0 {
+		stdImportLines = append(stdImportLines, "")
+	}
+	return append(stdImportLines, otherImportLines...)
+}
+
+// getEntityRootModel returns the model all field paths of the entity start from, ie. "Person" for "Person.ContactInfo.Email".
+func getEntityRootModel(r *registry.Registry, entity yaml.Entity) (yaml.Model, error) {
+	rootModelName := ""
+	for _, fieldName := range core.MapKeysSorted(entity.Fields) {
+		fieldPath := string(entity.Fields[fieldName].Type)
+		fieldRootName, _, _ := strings.Cut(fieldPath, ".")
+		if rootModelName == "" {
+			rootModelName = fieldRootName
+			continue
+		}
+		if fieldRootName != rootModelName {
+			return yaml.Model{}, fmt.Errorf("entity %s model mapper requires a single root model, found %s and %s", entity.Name, rootModelName, fieldRootName)
+		}
+	}
+	if rootModelName == "" {
+		return yaml.Model{}, fmt.Errorf("entity %s model mapper requires at least one field", entity.Name)
+	}
+
+	rootModel, modelErr := r.GetModel(rootModelName)
+	if modelErr != nil {
+		return yaml.Model{}, fmt.Errorf("entity %s references unknown root model: %s", entity.Name, rootModelName)
+	}
+	return rootModel, nil
+}
+
+// getEntityRelatedIDFieldRelations maps the related ID fields of an entity to their relations, ie. "CompanyID" -> "Company".
+func getEntityRelatedIDFieldRelations(entity yaml.Entity) map[string]string {
+	relatedIDFieldRelations := map[string]string{}
+	for relationName, relation := range entity.Related {
+		if yamlops.IsRelationPoly(relation.Type) && yamlops.IsRelationFor(relation.Type) {
+			continue
+		}
+		idFieldName := relationName + "ID"
+		if yamlops.IsRelationMany(relation.Type) {
+			idFieldName += "s"
+		}
+		relatedIDFieldRelations[idFieldName] = relationName
+	}
+	return relatedIDFieldRelations
+}
+
+// addDirectField copies a model field by following the entity field path through the relation pointers of the root model.
+func (b *entityModelMapperBody) addDirectField(config cfg.MorpheConfig, r *registry.Registry, rootModel yaml.Model, entityField godef.StructField, fieldPath yaml.ModelFieldPath) error {
+	terminalModel, terminalFieldName, terminalField, fieldErr := getModelFieldByPath(r, fieldPath)
+	if fieldErr != nil {
+		return fieldErr
+	}
+
+	pathParts := strings.Split(string(fieldPath), ".")
+	sourceExpr := "m"
+	currentModel := rootModel
+	for partIdx := 1; partIdx < len(pathParts)-1; partIdx++ {
+		relationName := pathParts[partIdx]
+		relationDef := currentModel.Related[relationName]
+		if !yamlops.IsRelationOne(relationDef.Type) || isModelPolyForRelation(relationDef) {
+			return fmt.Errorf("entity %s field %s cannot be mapped through %s relation %s", b.EntityName, entityField.Name, relationDef.Type, relationName)
+		}
+
+		sourceExpr += "." + relationName
+		b.addRelationCheck(entityField.Name, strings.Join(pathParts[:partIdx+1], "."), sourceExpr)
+
+		relatedModel, relatedErr := r.GetModel(yamlops.GetRelationTargetName(relationName, relationDef.Aliased))
+		if relatedErr != nil {
+			return fmt.Errorf("morphe entity field %s references invalid related model: %s", fieldPath, relationName)
+		}
+		currentModel = relatedModel
+	}
+	sourceExpr += "." + terminalFieldName
+
+	terminalPrimaryFieldName, _ := yamlops.GetModelPrimaryIdentifierFieldName(terminalModel)
+	isTypedID := config.MorpheModelsConfig.TypedIDs && terminalFieldName == terminalPrimaryFieldName
+	isSourceOptional := hasAttribute(terminalField.Attributes, "optional")
+	b.addAssignment(entityField, sourceExpr, string(fieldPath), isSourceOptional, isTypedID)
+	return nil
+}
+
+// addRelatedIDField copies the related ID(s) of the root model relation with the same name and type as the entity relation.
+// Entity relations without a matching model relation are left empty.
+func (b *entityModelMapperBody) addRelatedIDField(config cfg.MorpheConfig, r *registry.Registry, rootModel yaml.Model, entityField godef.StructField, relationName string, relation yaml.EntityRelation) error {
+	relationDef, hasRelation := rootModel.Related[relationName]
+	if !hasRelation || relationDef.Type != relation.Type {
+		return nil
+	}
+
+	targetModelName := yamlops.GetRelationTargetName(relationName, relationDef.Aliased)
+	if yamlops.IsRelationMany(relationDef.Type) && yamlops.IsRelationHas(relationDef.Type) {
+		targetModelName, _, _ = strings.Cut(targetModelName, ".")
+	}
+	relatedModelDef, relatedErr := r.GetModel(targetModelName)
+	if relatedErr != nil {
+		return fmt.Errorf("failed to get model '%s' for relation '%s': %w", targetModelName, relationName, relatedErr)
+	}
+	modelIDField, modelIDErr := getRelatedGoFieldForMorpheModelPrimaryID(config, relationName, targetModelName, relatedModelDef, relationDef, "")
+	if modelIDErr != nil {
+		return modelIDErr
+	}
+
+	sourceExpr := "m." + modelIDField.Name
+	isTypedID := config.MorpheModelsConfig.TypedIDs
+	if entityArray, isArray := entityField.Type.(godef.GoTypeArray); isArray {
+		b.addSliceAssignment(entityField.Name, entityArray.ValueType, sourceExpr, isTypedID)
+		return nil
+	}
+	_, isSourceOptional := modelIDField.Type.(godef.GoTypePointer)
+	b.addAssignment(entityField, sourceExpr, rootModel.Name+"."+modelIDField.Name, isSourceOptional, isTypedID)
+	return nil
+}
+
+// addRelationCheck returns an error from the mapper if a relation on a field path is not loaded. Each relation is checked once.
+func (b *entityModelMapperBody) addRelationCheck(fieldName string, relationPath string, relationExpr string) {
+	if slices.Contains(b.checkedRelationPaths, relationPath) {
+		return
+	}
+	b.checkedRelationPaths = append(b.checkedRelationPaths, relationPath)
+	b.UsesErrors = true
+	b.Lines = append(b.Lines,
+		fmt.Sprintf("\tif %s == nil {", relationExpr),
+		fmt.Sprintf("\t\treturn %s{}, errors.New(%q)", b.EntityName, fmt.Sprintf("mapping %s.%s: model relation %s is not loaded", b.EntityName, fieldName, relationPath)),
+		"\t}",
+	)
+}
+
+// addAssignment copies a single value, converting typed IDs to the underlying type of the entity field. Optional model fields
+// mapped to required entity fields return an error if they are not set, like relations that are not loaded.
+func (b *entityModelMapperBody) addAssignment(entityField godef.StructField, sourceExpr string, sourcePath string, isSourceOptional bool, isConverted bool) {
+	targetExpr := entityModelMapperVarName + "." + entityField.Name
+	valueType := entityField.Type
+	entityPointer, isTargetOptional := entityField.Type.(godef.GoTypePointer)
+	if isTargetOptional {
+		valueType = entityPointer.ValueType
+	}
+
+	valueExpr := sourceExpr
+	if isSourceOptional {
+		valueExpr = "*" + sourceExpr
+	}
+	if isConverted {
+		valueExpr = fmt.Sprintf("%s(%s)", valueType.GetSyntax(), valueExpr)
+		b.Imports = append(b.Imports, valueType.GetImports()...)
+	}
+	valueVarName := strcase.ToCamelCase(entityField.Name) + "Value"
+
+	switch {
+	case isSourceOptional && isTargetOptional:
+		b.Lines = append(b.Lines,
+			fmt.Sprintf("\tif %s != nil {", sourceExpr),
+			fmt.Sprintf("\t\t%s := %s", valueVarName, valueExpr),
+			fmt.Sprintf("\t\t%s = &%s", targetExpr, valueVarName),
+			"\t}",
+		)
+	case isSourceOptional:
+		b.UsesErrors = true
+		b.Lines = append(b.Lines,
+			fmt.Sprintf("\tif %s == nil {", sourceExpr),
+			fmt.Sprintf("\t\treturn %s{}, errors.New(%q)", b.EntityName, fmt.Sprintf("mapping %s.%s: model field %s is not set", b.EntityName, entityField.Name, sourcePath)),
+			"\t}",
+			fmt.Sprintf("\t%s = %s", targetExpr, valueExpr),
+		)
+	case isTargetOptional:
+		b.Lines = append(b.Lines,
+			fmt.Sprintf("\t%s := %s", valueVarName, valueExpr),
+			fmt.Sprintf("\t%s = &%s", targetExpr, valueVarName),
+		)
+	default:
+		b.Lines = append(b.Lines, fmt.Sprintf("\t%s = %s", targetExpr, valueExpr))
+	}
+}
+
+// addSliceAssignment copies the related IDs of a *Many relation, keeping nil slices nil.
+func (b *entityModelMapperBody) addSliceAssignment(fieldName string, valueType godef.GoType, sourceExpr string, isConverted bool) {
+	targetExpr := entityModelMapperVarName + "." + fieldName
+	valueSyntax := valueType.GetSyntax()
+	b.Imports = append(b.Imports, valueType.GetImports()...)
+
+	if !isConverted {
+		b.Lines = append(b.Lines, fmt.Sprintf("\t%s = append([]%s(nil), %s...)", targetExpr, valueSyntax, sourceExpr))
+		return
+	}
+	b.Lines = append(b.Lines,
+		fmt.Sprintf("\tif %s != nil {", sourceExpr),
+		fmt.Sprintf("\t\t%s = make([]%s, len(%s))", targetExpr, valueSyntax, sourceExpr),
+		fmt.Sprintf("\t\tfor idx, id := range %s {", sourceExpr),
+		fmt.Sprintf("\t\t\t%s[idx] = %s(id)", targetExpr, valueSyntax),
+		"\t\t}",
+		"\t}",
+	)
+}
@@ -0,0 +1,61 @@
+package compile
+
+import (
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/typemap"
+)
+
+// getModelFieldGoType returns the Go type of a (non-enum) model field, taking type overrides and support types into account.
+func getModelFieldGoType(config cfg.MorpheConfig, modelName string, fieldName string, fieldType yaml.ModelFieldType) (godef.GoType, bool) {
+	overrideType, hasOverride := config.MorpheTypeOverridesConfig.GetModelFieldOverride(modelName, fieldName, string(fieldType))
+	if hasOverride {
+		return overrideType, true
+	}
+	return typemap.GetMorpheModelFieldGoType(fieldType, getSupportTypes(config))
+}
+
+// getStructureFieldGoType returns the Go type of a (non-enum) structure field, taking type overrides and support types into account.
+func getStructureFieldGoType(config cfg.MorpheConfig, fieldType yaml.StructureFieldType) (godef.GoType, bool) {
+	overrideType, hasOverride := config.MorpheTypeOverridesConfig.GetFieldTypeOverride(string(fieldType))
+	if hasOverride {
+		return overrideType, true
+	}
+	return typemap.GetMorpheStructureFieldGoType(fieldType, getSupportTypes(config))
+}
+
+// getModelIDGoType returns the named ID type of a model (ie. "PersonID") if typed IDs are enabled, otherwise the primary identifier type itself.
+func getModelIDGoType(config cfg.MorpheConfig, modelName string, primaryIDType godef.GoType) godef.GoType {
+	if !config.MorpheModelsConfig.TypedIDs {
+		return primaryIDType
+	}
+	return godef.GoTypeDerived{
+		Name:     getModelIDTypeName(modelName),
+		BaseType: primaryIDType,
+	}
+}
+
+func getModelIDTypeName(modelName string) string {
+	return modelName + "ID"
+}
+
+// findStructIDType returns the named ID type declared alongside the struct, ie. "PersonID" for the "Person" model.
+func findStructIDType(structDefinition *godef.Struct) (godef.GoTypeDerived, bool) {
+	idTypeName := getModelIDTypeName(structDefinition.Name)
+	for _, structField := range structDefinition.Fields {
+		idType, isDerived := structField.Type.(godef.GoTypeDerived)
+		if isDerived && idType.PackagePath == "" && idType.Name == idTypeName {
+			return idType, true
+		}
+	}
+	return godef.GoTypeDerived{}, false
+}
+
+func getSupportTypes(config cfg.MorpheConfig) typemap.SupportTypes {
+	return typemap.SupportTypes{
+		Date:      config.MorpheSupportConfig.GetDateType(),
+		Protected: config.MorpheSupportConfig.GetProtectedType(),
+		Sealed:    config.MorpheSupportConfig.GetSealedType(),
+	}
+}
@@ -0,0 +1,21 @@
+package compile
+
+import (
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/write"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+)
+
+// GetAllGeneratedFiles returns the files written (or checked) by all configured writers since the last call, sorted by path.
+// Writers that do not implement write.GeneratedFileReporter are skipped.
+func GetAllGeneratedFiles(config MorpheCompileConfig) []gofile.GeneratedFile {
+	allGeneratedFiles := []gofile.GeneratedFile{}
+	for _, writer := range config.allWriters() {
+		reporter, isReporter := writer.(write.GeneratedFileReporter)
+		if !isReporter {
+			continue
+		}
+		allGeneratedFiles = append(allGeneratedFiles, reporter.GetGeneratedFiles()...)
+	}
+	gofile.SortGeneratedFiles(allGeneratedFiles)
+	return allGeneratedFiles
+}
@@ -110,11 +110,16 @@ func getIdentifierStruct(
 	return &godef.Struct{
 		Package: pkg,
 		Imports: structImports,
-		Name:    fmt.Sprintf("%sID%s", parentName, strcase.ToPascalCase(identifierName)),
+		Name:    getIdentifierStructName(parentName, identifierName),
 		Fields:  fields,
 	}, nil
 }
 
+// getIdentifierStructName returns the name of an identifier struct, ie. "PersonIDPrimary".
+func getIdentifierStructName(parentName string, identifierName string) string {
+	return fmt.Sprintf("%sID%s", parentName, strcase.ToPascalCase(identifierName))
+}
+
 func getIdentifierGetter(
 	config IdentifierConfig,
 	parentName string,
@@ -0,0 +1,107 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/go-util/inflect"
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/morphe-go/pkg/yamlops"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+)
+
+const jsonTagPrefix = "json:\""
+
+// applyJSONTagsConfig rewrites the JSON tags of all struct fields with the configured options. Fields without a JSON tag
+// (if no field casing is set) get one named after the Go field if any option applies to them.
+func applyJSONTagsConfig(config cfg.JSONTagsConfig, structName string, allFields []godef.StructField, relationFieldNames []string) {
+	if !config.IsEnabled() {
+		return
+	}
+
+	for fieldIdx := range allFields {
+		field := &allFields[fieldIdx]
+		jsonTagIdx := slices.IndexFunc(field.Tags, func(tag string) bool {
+			return strings.HasPrefix(tag, jsonTagPrefix)
+		})
+
+		jsonName := field.Name
+		var jsonOptions []string
+		if jsonTagIdx >= 0 {
+			tagParts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(field.Tags[jsonTagIdx], jsonTagPrefix), "\""), ",")
+			jsonName, jsonOptions = tagParts[0], tagParts[1:]
+		}
+		// Fields excluded by the compiler (ie. polymorphic marker interfaces) stay excluded
+		if jsonName == "-" {
+			continue
+		}
+
+		override, hasOverride := config.GetFieldOverride(structName, field.Name)
+		if hasOverride && override.Name != "" {
+			jsonName = override.Name
+		}
+		if slices.Contains(relationFieldNames, field.Name) {
+			switch config.Relations {
+			case cfg.JSONRelationsExclude:
+				jsonName = "-"
+			case cfg.JSONRelationsOmitEmpty:
+				jsonOptions = addJSONOption(jsonOptions, string(cfg.JSONOmitEmpty))
+			}
+		}
+		if _, isPointer := field.Type.(godef.GoTypePointer); isPointer && config.OmitOptional != cfg.JSONOmitNone {
+			jsonOptions = addJSONOption(jsonOptions, string(config.OmitOptional))
+		}
+		if hasOverride && override.String {
+			jsonOptions = addJSONOption(jsonOptions, "string")
+		}
+		if jsonName == "-" {
+			jsonOptions = nil
+		}
+
+		jsonTag := fmt.Sprintf("%s%s\"", jsonTagPrefix, strings.Join(append([]string{jsonName}, jsonOptions...), ","))
+		if jsonTagIdx >= 0 {
+			field.Tags[jsonTagIdx] = jsonTag
+			continue
+		}
+		if jsonName == field.Name && len(jsonOptions) == 0 {
+			continue
+		}
+		field.Tags = slices.Insert(field.Tags, getJSONTagInsertIdx(field.Tags), jsonTag)
+	}
+}
+
+func addJSONOption(jsonOptions []string, option string) []string {
+	if slices.Contains(jsonOptions, option) {
+		return jsonOptions
+	}
+	return append(jsonOptions, option)
+}
+
+// getJSONTagInsertIdx places new JSON tags after the morphe tag, where buildFieldTags adds them.
+func getJSONTagInsertIdx(tags []string) int {
+	if len(tags) > 0 && strings.HasPrefix(tags[0], "morphe:") {
+		return 1
+	}
+	return 0
+}
+
+// getRelationFieldNames returns the names of the fields holding related structs, ie. "Company" or "Notes".
+// Polymorphic For* relations don't have such a field, or their marker interface is excluded from JSON anyway.
+func getRelationFieldNames(relations map[string]yaml.ModelRelation) []string {
+	relationFieldNames := []string{}
+	for _, relationName := range core.MapKeysSorted(relations) {
+		relationDef := relations[relationName]
+		if isModelPolyForRelation(relationDef) {
+			continue
+		}
+		if yamlops.IsRelationMany(relationDef.Type) {
+			relationFieldNames = append(relationFieldNames, inflect.Plural(relationName))
+			continue
+		}
+		relationFieldNames = append(relationFieldNames, relationName)
+	}
+	return relationFieldNames
+}
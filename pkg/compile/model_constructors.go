@@ -0,0 +1,216 @@
+package compile
+
+import (
+	"fmt"
+	"go/token"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/go-util/strcase"
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/morphe-go/pkg/registry"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/morphe-go/pkg/yamlops"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+)
+
+// modelConstructorField is a model field set by the generated New<Model> constructor (required) or a With<Field> method.
+type modelConstructorField struct {
+	Name     string
+	Type     godef.GoType
+	Required bool
+}
+
+// AllMorpheModelConstructors returns the rendered contents of the constructors of all models (definition name -> contents)
+// if constructors are enabled, ie. "NewPerson" for the "Person" model.
+func AllMorpheModelConstructors(config MorpheCompileConfig, r *registry.Registry) (map[string]string, error) {
+	allConstructorDefs := map[string]string{}
+	if !config.MorpheModelsConfig.Constructors {
+		return allConstructorDefs, nil
+	}
+	if r == nil {
+		return nil, ErrNoRegistry
+	}
+
+	allErrs := CompileErrors{}
+	allModels := r.GetAllModels()
+	for _, modelName := range core.MapKeysSorted(allModels) {
+		constructorContents, constructorErr := getModelConstructorContents(config.MorpheConfig, r, allModels[modelName])
+		if constructorErr != nil {
+			if addErr := addCompileError(config, &allErrs, CompileErrorKindModel, modelName, constructorErr); addErr != nil {
+				return nil, addErr
+			}
+			continue
+		}
+		allConstructorDefs[getModelConstructorName(modelName)] = constructorContents
+	}
+	if compileErrs := allErrs.Err(); compileErrs != nil {
+		return nil, compileErrs
+	}
+	return allConstructorDefs, nil
+}
+
+func getModelConstructorContents(config cfg.MorpheConfig, r *registry.Registry, model yaml.Model) (string, error) {
+	modelStruct, modelStructErr := getModelStruct(config, r, model)
+	if modelStructErr != nil {
+		return "", modelStructErr
+	}
+	allFields, fieldsErr := getModelConstructorFields(r, model, modelStruct)
+	if fieldsErr != nil {
+		return "", fieldsErr
+	}
+	return getModelConstructorFileContents(config.MorpheModelsConfig.Package, model.Name, allFields), nil
+}
+
+func getModelConstructorName(modelName string) string {
+	return "New" + modelName
+}
+
+// getModelConstructorFields returns the direct fields and ForOne relation IDs of a model in struct field order. Optional fields,
+// optional relation IDs and AutoIncrement fields (assigned by the database) are not required by the constructor.
+func getModelConstructorFields(r *registry.Registry, model yaml.Model, modelStruct *godef.Struct) ([]modelConstructorField, error) {
+	relatedIDFieldRelations := map[string]yaml.ModelRelation{}
+	for relationName, relationDef := range model.Related {
+		if relationDef.Type != "ForOne" {
+			continue
+		}
+		targetModelName := yamlops.GetRelationTargetName(relationName, relationDef.Aliased)
+		relatedModelDef, relatedErr := r.GetModel(targetModelName)
+		if relatedErr != nil {
+			return nil, fmt.Errorf("failed to get model '%s' for relation '%s': %w", targetModelName, relationName, relatedErr)
+		}
+		relatedPrimaryIDFieldName, relatedIDErr := yamlops.GetModelPrimaryIdentifierFieldName(relatedModelDef)
+		if relatedIDErr != nil {
+			return nil, fmt.Errorf("related %w", relatedIDErr)
+		}
+		relatedIDFieldRelations[relationName+relatedPrimaryIDFieldName] = relationDef
+	}
+
+	allFields := []modelConstructorField{}
+	for _, structField := range modelStruct.Fields {
+		if fieldDef, isDirectField := model.Fields[structField.Name]; isDirectField {
+			allFields = append(allFields, modelConstructorField{
+				Name:     structField.Name,
+				Type:     structField.Type,
+				Required: !hasAttribute(fieldDef.Attributes, "optional") && fieldDef.Type != yaml.ModelFieldTypeAutoIncrement,
+			})
+			continue
+		}
+		if relationDef, isRelatedIDField := relatedIDFieldRelations[structField.Name]; isRelatedIDField {
+			allFields = append(allFields, modelConstructorField{
+				Name:     structField.Name,
+				Type:     structField.Type,
+				Required: !hasAttribute(relationDef.Attributes, "optional"),
+			})
+		}
+	}
+	return allFields, nil
+}
+
+// getModelConstructorParamName returns the parameter name of a field, ie. "companyID" for "CompanyID".
+func getModelConstructorParamName(fieldName string, receiverName string) string {
+	paramName := strcase.ToCamelCase(fieldName)
+	if token.IsKeyword(paramName) || paramName == receiverName {
+		return paramName + "Value"
+	}
+	return paramName
+}
+
+func getModelConstructorFileContents(modelsPackage godef.Package, modelName string, allFields []modelConstructorField) string {
+	constructorName := getModelConstructorName(modelName)
+	allParams := []string{}
+	allAssignLines := []string{}
+	allImports := []string{}
+	for _, field := range allFields {
+		if !field.Required {
+			continue
+		}
+		paramName := getModelConstructorParamName(field.Name, "")
+		allParams = append(allParams, fmt.Sprintf("%s %s", paramName, getLocalTypeSyntax(modelsPackage, field.Type)))
+		allAssignLines = append(allAssignLines, fmt.Sprintf("\t\t%s: %s,", field.Name, paramName))
+		for _, importPath := range field.Type.GetImports() {
+			if importPath != "" && importPath != modelsPackage.Path && !slices.Contains(allImports, importPath) {
+				allImports = append(allImports, importPath)
+			}
+		}
+	}
+	sort.Strings(allImports)
+
+	allLines := []string{
+		gofile.GeneratedFileHeader,
+		"",
+		fmt.Sprintf("package %s", modelsPackage.Name),
+		"",
+	}
+	if len(allImports) > 0 {
+		allLines = append(allLines, "import (")
+		allLines = append(allLines, getGroupedImportLines(allImports)...)
+		allLines = append(allLines, ")", "")
+	}
+	allLines = append(allLines,
+		fmt.Sprintf("// %s returns a %s with all required fields and relation IDs. Optional fields are set with the With methods.", constructorName, modelName),
+		fmt.Sprintf("func %s(%s) %s {", constructorName, strings.Join(allParams, ", "), modelName),
+	)
+	if len(allAssignLines) == 0 {
+		allLines = append(allLines, fmt.Sprintf("\treturn %s{}", modelName))
+	} else {
+		allLines = append(allLines, fmt.Sprintf("\treturn %s{", modelName))
+		allLines = append(allLines, allAssignLines...)
+		allLines = append(allLines, "\t}")
+	}
+	allLines = append(allLines, "}", "")
+	return strings.Join(allLines, "\n")
+}
+
+// getLocalTypeSyntax renders a type declared in the current package without its package name.
+func getLocalTypeSyntax(currentPackage godef.Package, goType godef.GoType) string {
+	structType, isStructType := goType.(godef.GoTypeStruct)
+	if isStructType && structType.PackagePath == currentPackage.Path {
+		return goType.GetSyntaxLocal()
+	}
+	return goType.GetSyntax()
+}
+
+// addModelWithMethods adds a With<Field> method per field that is not required by the constructor. The methods return a copy
+// of the model, so they can be chained after the constructor: NewPerson(...).WithNickname("Al").
+func addModelWithMethods(config cfg.MorpheModelsConfig, r *registry.Registry, model yaml.Model, modelStruct *godef.Struct) error {
+	allFields, fieldsErr := getModelConstructorFields(r, model, modelStruct)
+	if fieldsErr != nil {
+		return fieldsErr
+	}
+
+	receiverName := config.ReceiverName
+	receiverType := godef.GoTypeStruct{
+		PackagePath: config.Package.Path,
+		Name:        model.Name,
+	}
+	for _, field := range allFields {
+		if field.Required {
+			continue
+		}
+		paramName := getModelConstructorParamName(field.Name, receiverName)
+		paramType := field.Type
+		valueExpr := paramName
+		if pointerType, isPointer := field.Type.(godef.GoTypePointer); isPointer {
+			paramType = pointerType.ValueType
+			valueExpr = "&" + paramName
+		}
+		modelStruct.Methods = append(modelStruct.Methods, godef.StructMethod{
+			ReceiverName: receiverName,
+			ReceiverType: receiverType,
+			Name:         "With" + field.Name,
+			Parameters: map[string]godef.GoType{
+				paramName: paramType,
+			},
+			ReturnTypes: []godef.GoType{receiverType},
+			BodyLines: []string{
+				fmt.Sprintf("\t%s.%s = %s", receiverName, field.Name, valueExpr),
+				fmt.Sprintf("\treturn %s", receiverName),
+			},
+		})
+	}
+	return nil
+}
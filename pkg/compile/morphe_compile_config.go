@@ -9,6 +9,7 @@ import (
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/hook"
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/write"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
 )
 
 type MorpheCompileConfig struct {
@@ -29,13 +30,42 @@ type MorpheCompileConfig struct {
 	EnumWriter write.GoEnumWriter
 	EnumHooks  hook.CompileMorpheEnum
 
+	// SupportWriter writes the support definitions enabled in cfg.MorpheSupportConfig, ie. the civil Date type
+	SupportWriter write.GoSupportWriter
+
 	WriteStructHooks hook.WriteGoStruct
 	WriteGoEnumHooks hook.WriteGoEnum
+
+	// RemoveStaleFiles deletes previously generated files that are no longer produced by the registry after a successful compilation.
+	// Only writers implementing write.StaleFileRemover are cleaned up, and each writer is expected to own its target directory.
+	// Disabled by default, since it deletes files from the output directories.
+	RemoveStaleFiles bool
+
+	// AggregateErrors compiles every definition instead of stopping at the first failure, and returns all failures as
+	// sorted CompileErrors. Nothing is written if any definition fails.
+	AggregateErrors bool
 }
 
 func DefaultMorpheCompileConfig(
 	yamlRegistryPath string,
 	baseOutputDirPath string,
+) MorpheCompileConfig {
+	return defaultMorpheCompileConfig(yamlRegistryPath, baseOutputDirPath, nil)
+}
+
+// DefaultMorpheCompileConfigFS is like DefaultMorpheCompileConfig, but writes all definitions to the output filesystem
+// (ie. "models/person.go") instead of a base output directory on disk.
+func DefaultMorpheCompileConfigFS(
+	yamlRegistryPath string,
+	outputFS gofile.OutputFS,
+) MorpheCompileConfig {
+	return defaultMorpheCompileConfig(yamlRegistryPath, "", outputFS)
+}
+
+func defaultMorpheCompileConfig(
+	yamlRegistryPath string,
+	baseOutputDirPath string,
+	outputFS gofile.OutputFS,
 ) MorpheCompileConfig {
 	return MorpheCompileConfig{
 		MorpheLoadRegistryConfig: rcfg.MorpheLoadRegistryConfig{
@@ -68,22 +98,30 @@ func DefaultMorpheCompileConfig(
 				},
 				ReceiverName: "e",
 			},
+			MorpheSupportConfig: cfg.MorpheSupportConfig{
+				Package: godef.Package{
+					Name: "support",
+				},
+			},
 		},
 
 		RegistryHooks: r.LoadMorpheRegistryHooks{},
 
 		EnumWriter: &MorpheEnumFileWriter{
 			TargetDirPath: path.Join(baseOutputDirPath, "enums"),
+			OutputFS:      outputFS,
 		},
 		EnumHooks: hook.CompileMorpheEnum{},
 
 		ModelWriter: &MorpheStructFileWriter{
 			TargetDirPath: path.Join(baseOutputDirPath, "models"),
+			OutputFS:      outputFS,
 		},
 		ModelHooks: hook.CompileMorpheModel{},
 
 		EntityWriter: &MorpheStructFileWriter{
 			TargetDirPath: path.Join(baseOutputDirPath, "entities"),
+			OutputFS:      outputFS,
 		},
 		EntityHooks: hook.CompileMorpheEntity{},
 
@@ -92,7 +130,25 @@ func DefaultMorpheCompileConfig(
 
 		StructureWriter: &MorpheStructFileWriter{
 			TargetDirPath: path.Join(baseOutputDirPath, "structures"),
+			OutputFS:      outputFS,
 		},
 		StructureHooks: hook.CompileMorpheStructure{},
+
+		SupportWriter: &MorpheSupportFileWriter{
+			TargetDirPath: path.Join(baseOutputDirPath, "support"),
+			OutputFS:      outputFS,
+		},
+	}
+}
+
+// allWriters returns the writers of all definition kinds, as values to check for the optional writer interfaces
+// (ie. write.StaleFileRemover). Writers that are not set are nil.
+func (config MorpheCompileConfig) allWriters() []any {
+	return []any{
+		config.EnumWriter,
+		config.ModelWriter,
+		config.StructureWriter,
+		config.EntityWriter,
+		config.SupportWriter,
 	}
 }
@@ -2,16 +2,33 @@ package compile
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/kalo-build/go-util/core"
 	"github.com/kalo-build/go-util/strcase"
 	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/write"
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
 )
 
 type MorpheEnumFileWriter struct {
 	TargetDirPath string
+
+	// OutputFS is the filesystem definition files are written to, the local filesystem is used if nil
+	OutputFS gofile.OutputFS
+
+	// CheckOnly compares the rendered definitions with the existing files in the target directory instead of writing them
+	CheckOnly bool
+
+	// EnumsConfig controls the optional methods generated for each enum, it is set from the compile config before writing
+	EnumsConfig cfg.MorpheEnumsConfig
+
+	// EnumDocs holds the doc comments rendered on the enums (enum name -> doc), it is set from the compile config before writing
+	EnumDocs map[string]write.EnumDoc
+
+	definitionFileTracker
 }
 
 func (w *MorpheEnumFileWriter) WriteEnum(enumDefinition *godef.Enum) ([]byte, error) {
@@ -25,28 +42,410 @@ func (w *MorpheEnumFileWriter) WriteEnum(enumDefinition *godef.Enum) ([]byte, er
 		return nil, enumContentsErr
 	}
 
-	return gofile.WriteGoDefinitionFile(w.TargetDirPath, enumDefinition.Name, enumFileContents)
+	return w.writeDefinitionFile(w.OutputFS, w.TargetDirPath, w.CheckOnly, enumDefinition.Name, enumFileContents)
+}
+
+// SetEnumsConfig sets the enums config used for all following writes.
+func (w *MorpheEnumFileWriter) SetEnumsConfig(enumsConfig cfg.MorpheEnumsConfig) {
+	w.EnumsConfig = enumsConfig
+}
+
+// SetEnumDocs sets the doc comments used for all following writes.
+func (w *MorpheEnumFileWriter) SetEnumDocs(allEnumDocs map[string]write.EnumDoc) {
+	w.EnumDocs = allEnumDocs
+}
+
+// SetCheckOnly enables or disables check mode.
+func (w *MorpheEnumFileWriter) SetCheckOnly(checkOnly bool) {
+	w.CheckOnly = checkOnly
+}
+
+// GetCheckResult returns all differences found in check mode since the last call, including generated files that were not rendered again.
+func (w *MorpheEnumFileWriter) GetCheckResult() (gofile.CheckResult, error) {
+	return w.getCheckResult(w.OutputFS, w.TargetDirPath)
+}
+
+// RemoveStaleFiles deletes all generated files in the target directory that were not written since the last cleanup.
+func (w *MorpheEnumFileWriter) RemoveStaleFiles() ([]string, error) {
+	return w.removeStaleFiles(w.OutputFS, w.TargetDirPath)
 }
 
 func (w *MorpheEnumFileWriter) getAllEnumLines(enumDefinition *godef.Enum) ([]string, error) {
-	allEnumLines := []string{
-		fmt.Sprintf("package %s", enumDefinition.Package.Name),
+	enumImports := map[string]any{
+		"fmt": nil,
+	}
+
+	enumDoc := w.EnumDocs[enumDefinition.Name]
+	allDeclarationLines := getDocCommentLines("", enumDoc.Lines)
+	allDeclarationLines = append(allDeclarationLines,
 		fmt.Sprintf("type %s %s", enumDefinition.Name, enumDefinition.Type.BaseType.GetSyntaxLocal()),
 		"const (",
-	}
+	)
 
 	for _, enumEntry := range enumDefinition.Entries {
+		allDeclarationLines = append(allDeclarationLines, getDocCommentLines("\t", enumDoc.EntryLines[enumEntry.Name])...)
 		entryName := strcase.ToPascalCase(enumEntry.Name)
 		entryValue := w.formatEnumValue(enumEntry.Value)
 		enumEntryLine := fmt.Sprintf("\t%s %s = %v",
 			entryName, enumDefinition.Name, entryValue)
-		allEnumLines = append(allEnumLines, enumEntryLine)
+		allDeclarationLines = append(allDeclarationLines, enumEntryLine)
 	}
 
-	allEnumLines = append(allEnumLines, ")")
+	allDeclarationLines = append(allDeclarationLines, ")")
+	allDeclarationLines = append(allDeclarationLines, "")
+
+	helperLines := w.getAllEnumHelperLines(enumDefinition)
+	allDeclarationLines = append(allDeclarationLines, helperLines...)
+
+	marshallingLines := w.getAllEnumMarshallingLines(enumDefinition, enumImports)
+	allDeclarationLines = append(allDeclarationLines, marshallingLines...)
+
+	allEnumLines := []string{
+		gofile.GeneratedFileHeader,
+		"",
+		fmt.Sprintf("package %s", enumDefinition.Package.Name),
+		"",
+	}
+	allEnumLines = append(allEnumLines, w.getAllEnumImportLines(enumImports)...)
+	allEnumLines = append(allEnumLines, "")
+	allEnumLines = append(allEnumLines, allDeclarationLines...)
 	return allEnumLines, nil
 }
 
+func (w *MorpheEnumFileWriter) getAllEnumImportLines(enumImports map[string]any) []string {
+	allImportLines := []string{
+		"import (",
+	}
+
+	sortedImports := core.MapKeysSorted(enumImports)
+	for _, enumImport := range sortedImports {
+		allImportLines = append(allImportLines, `"`+enumImport+`"`)
+	}
+
+	allImportLines = append(allImportLines, ")")
+	return allImportLines
+}
+
+// getAllEnumHelperLines returns the All<Enum>Values, IsValid, String (non-string enums only), Parse<Enum> and
+// <Enum>FromValue helpers. Parse<Enum> looks up the entry name for all enum types, <Enum>FromValue the value.
+func (w *MorpheEnumFileWriter) getAllEnumHelperLines(enumDefinition *godef.Enum) []string {
+	enumName := enumDefinition.Name
+	baseTypeName := enumDefinition.Type.BaseType.GetSyntaxLocal()
+	zeroValue := "0"
+	if enumDefinition.Type.BaseType == godef.GoTypeString {
+		zeroValue = `""`
+	}
+
+	allHelperLines := []string{
+		fmt.Sprintf("// All%sValues returns all declared %s values.", enumName, enumName),
+		fmt.Sprintf("func All%sValues() []%s {", enumName, enumName),
+		fmt.Sprintf("\treturn []%s{", enumName),
+	}
+	for _, enumEntry := range enumDefinition.Entries {
+		allHelperLines = append(allHelperLines, fmt.Sprintf("\t\t%s,", strcase.ToPascalCase(enumEntry.Name)))
+	}
+	allHelperLines = append(allHelperLines,
+		"\t}",
+		"}",
+		"",
+		fmt.Sprintf("// IsValid returns true if the value is one of the declared %s values.", enumName),
+		fmt.Sprintf("func (e %s) IsValid() bool {", enumName),
+		fmt.Sprintf("\tfor _, enumValue := range All%sValues() {", enumName),
+		"\t\tif e == enumValue {",
+		"\t\t\treturn true",
+		"\t\t}",
+		"\t}",
+		"\treturn false",
+		"}",
+		"",
+	)
+
+	if enumDefinition.Type.BaseType != godef.GoTypeString {
+		allHelperLines = append(allHelperLines, w.getEnumStringMethodLines(enumDefinition)...)
+		allHelperLines = append(allHelperLines, "")
+	}
+
+	allHelperLines = append(allHelperLines,
+		fmt.Sprintf("// Parse%s returns the %s with the given entry name, or an error if no such entry is declared.", enumName, enumName),
+		fmt.Sprintf("func Parse%s(name string) (%s, error) {", enumName, enumName),
+		"\tswitch name {",
+	)
+	for _, enumEntry := range enumDefinition.Entries {
+		allHelperLines = append(allHelperLines,
+			fmt.Sprintf("\tcase %q:", strings.TrimPrefix(enumEntry.Name, enumName)),
+			fmt.Sprintf("\t\treturn %s, nil", strcase.ToPascalCase(enumEntry.Name)),
+		)
+	}
+	allHelperLines = append(allHelperLines,
+		"\t}",
+		fmt.Sprintf("\treturn %s, %s", zeroValue, w.getParseErrorExpression(enumName, "name")),
+		"}",
+		"",
+		fmt.Sprintf("// %sFromValue returns the %s with the given value, or an error if the value is not declared.", enumName, enumName),
+		fmt.Sprintf("func %sFromValue(value %s) (%s, error) {", enumName, baseTypeName, enumName),
+		fmt.Sprintf("\tenumValue := %s(value)", enumName),
+		"\tif !enumValue.IsValid() {",
+		fmt.Sprintf("\t\treturn %s, %s", zeroValue, w.getParseErrorExpression(enumName, "value")),
+		"\t}",
+		"\treturn enumValue, nil",
+		"}",
+		"",
+	)
+	return allHelperLines
+}
+
+// getParseErrorExpression returns the error returned by Parse<Enum> and <Enum>FromValue, which is the typed invalid value
+// error if any marshalling is generated.
+func (w *MorpheEnumFileWriter) getParseErrorExpression(enumName string, parameterName string) string {
+	if w.EnumsConfig.HasMarshalling() {
+		return fmt.Sprintf("%s{Value: %s}", getInvalidEnumErrorName(enumName), parameterName)
+	}
+	return fmt.Sprintf("fmt.Errorf(\"invalid %s %s: %%#v\", %s)", enumName, parameterName, parameterName)
+}
+
+// getAllEnumMarshallingLines returns the typed invalid value error and all marshalling methods enabled in the enums config.
+// All imports required by the returned lines are added to enumImports.
+func (w *MorpheEnumFileWriter) getAllEnumMarshallingLines(enumDefinition *godef.Enum, enumImports map[string]any) []string {
+	if !w.EnumsConfig.HasMarshalling() {
+		return nil
+	}
+
+	enumName := enumDefinition.Name
+	errorName := getInvalidEnumErrorName(enumName)
+	allMarshallingLines := []string{
+		fmt.Sprintf("// %s is returned when a value is not one of the declared %s values.", errorName, enumName),
+		fmt.Sprintf("type %s struct {", errorName),
+		"\tValue any",
+		"}",
+		"",
+		fmt.Sprintf("func (e %s) Error() string {", errorName),
+		fmt.Sprintf("\treturn fmt.Sprintf(\"invalid %s value: %%#v\", e.Value)", enumName),
+		"}",
+		"",
+	}
+
+	// Numeric values are parsed from and formatted as text
+	if (w.EnumsConfig.TextMarshalling || w.EnumsConfig.SQLMarshalling) && enumDefinition.Type.BaseType != godef.GoTypeString {
+		enumImports["strconv"] = nil
+	}
+	if w.EnumsConfig.TextMarshalling {
+		allMarshallingLines = append(allMarshallingLines, w.getEnumTextMarshallingLines(enumDefinition)...)
+	}
+	if w.EnumsConfig.JSONMarshalling {
+		enumImports["encoding/json"] = nil
+		allMarshallingLines = append(allMarshallingLines, w.getEnumJSONMarshallingLines(enumDefinition)...)
+	}
+	if w.EnumsConfig.SQLMarshalling {
+		enumImports["database/sql/driver"] = nil
+		allMarshallingLines = append(allMarshallingLines, w.getEnumSQLMarshallingLines(enumDefinition)...)
+	}
+	return allMarshallingLines
+}
+
+// getEnumTextMarshallingLines returns MarshalText and UnmarshalText methods, encoding the enum as its underlying value
+// like the JSON and SQL methods, ie. "2" rather than the entry name of a numeric enum.
+func (w *MorpheEnumFileWriter) getEnumTextMarshallingLines(enumDefinition *godef.Enum) []string {
+	enumName := enumDefinition.Name
+	errorName := getInvalidEnumErrorName(enumName)
+	baseTypeName := enumDefinition.Type.BaseType.GetSyntaxLocal()
+
+	textExpression := "[]byte(e)"
+	valueExpression := "string(text)"
+	parseLines := []string{}
+	switch enumDefinition.Type.BaseType {
+	case godef.GoTypeInt:
+		textExpression = "[]byte(strconv.FormatInt(int64(e), 10))"
+		parseLines = []string{
+			"\tvalue, parseErr := strconv.ParseInt(string(text), 10, 64)",
+		}
+	case godef.GoTypeFloat:
+		textExpression = "[]byte(strconv.FormatFloat(float64(e), 'g', -1, 64))"
+		parseLines = []string{
+			"\tvalue, parseErr := strconv.ParseFloat(string(text), 64)",
+		}
+	}
+	if enumDefinition.Type.BaseType != godef.GoTypeString {
+		valueExpression = fmt.Sprintf("%s(value)", baseTypeName)
+		parseLines = append(parseLines,
+			"\tif parseErr != nil {",
+			fmt.Sprintf("\t\treturn %s{Value: string(text)}", errorName),
+			"\t}",
+		)
+	}
+
+	allTextLines := []string{
+		"// MarshalText implements encoding.TextMarshaler.",
+		fmt.Sprintf("func (e %s) MarshalText() ([]byte, error) {", enumName),
+		"\tif !e.IsValid() {",
+		fmt.Sprintf("\t\treturn nil, %s{Value: %s(e)}", errorName, baseTypeName),
+		"\t}",
+		fmt.Sprintf("\treturn %s, nil", textExpression),
+		"}",
+		"",
+		"// UnmarshalText implements encoding.TextUnmarshaler.",
+		fmt.Sprintf("func (e *%s) UnmarshalText(text []byte) error {", enumName),
+	}
+	allTextLines = append(allTextLines, parseLines...)
+	allTextLines = append(allTextLines,
+		fmt.Sprintf("\tenumValue, fromValueErr := %sFromValue(%s)", enumName, valueExpression),
+		"\tif fromValueErr != nil {",
+		"\t\treturn fromValueErr",
+		"\t}",
+		"\t*e = enumValue",
+		"\treturn nil",
+		"}",
+		"",
+	)
+	return allTextLines
+}
+
+// getEnumJSONMarshallingLines returns MarshalJSON and UnmarshalJSON methods, encoding the enum as its underlying value.
+func (w *MorpheEnumFileWriter) getEnumJSONMarshallingLines(enumDefinition *godef.Enum) []string {
+	enumName := enumDefinition.Name
+	errorName := getInvalidEnumErrorName(enumName)
+	baseTypeName := enumDefinition.Type.BaseType.GetSyntaxLocal()
+
+	return []string{
+		"// MarshalJSON implements json.Marshaler.",
+		fmt.Sprintf("func (e %s) MarshalJSON() ([]byte, error) {", enumName),
+		"\tif !e.IsValid() {",
+		fmt.Sprintf("\t\treturn nil, %s{Value: %s(e)}", errorName, baseTypeName),
+		"\t}",
+		fmt.Sprintf("\treturn json.Marshal(%s(e))", baseTypeName),
+		"}",
+		"",
+		"// UnmarshalJSON implements json.Unmarshaler.",
+		fmt.Sprintf("func (e *%s) UnmarshalJSON(data []byte) error {", enumName),
+		fmt.Sprintf("\tvar value %s", baseTypeName),
+		"\tunmarshalErr := json.Unmarshal(data, &value)",
+		"\tif unmarshalErr != nil {",
+		"\t\treturn unmarshalErr",
+		"\t}",
+		fmt.Sprintf("\tenumValue := %s(value)", enumName),
+		"\tif !enumValue.IsValid() {",
+		fmt.Sprintf("\t\treturn %s{Value: value}", errorName),
+		"\t}",
+		"\t*e = enumValue",
+		"\treturn nil",
+		"}",
+		"",
+	}
+}
+
+// getEnumSQLMarshallingLines returns Value (driver.Valuer) and Scan (sql.Scanner) methods, storing the enum as its underlying value.
+func (w *MorpheEnumFileWriter) getEnumSQLMarshallingLines(enumDefinition *godef.Enum) []string {
+	enumName := enumDefinition.Name
+	errorName := getInvalidEnumErrorName(enumName)
+	baseTypeName := enumDefinition.Type.BaseType.GetSyntaxLocal()
+
+	driverValueExpression := "string(e)"
+	switch enumDefinition.Type.BaseType {
+	case godef.GoTypeInt:
+		driverValueExpression = "int64(e)"
+	case godef.GoTypeFloat:
+		driverValueExpression = "float64(e)"
+	}
+
+	allSQLLines := []string{
+		"// Value implements driver.Valuer.",
+		fmt.Sprintf("func (e %s) Value() (driver.Value, error) {", enumName),
+		"\tif !e.IsValid() {",
+		fmt.Sprintf("\t\treturn nil, %s{Value: %s(e)}", errorName, baseTypeName),
+		"\t}",
+		fmt.Sprintf("\treturn %s, nil", driverValueExpression),
+		"}",
+		"",
+		"// Scan implements sql.Scanner.",
+		fmt.Sprintf("func (e *%s) Scan(src any) error {", enumName),
+		fmt.Sprintf("\tvar enumValue %s", enumName),
+		"\tswitch value := src.(type) {",
+	}
+
+	switch enumDefinition.Type.BaseType {
+	case godef.GoTypeString:
+		allSQLLines = append(allSQLLines,
+			"\tcase string:",
+			fmt.Sprintf("\t\tenumValue = %s(value)", enumName),
+			"\tcase []byte:",
+			fmt.Sprintf("\t\tenumValue = %s(value)", enumName),
+		)
+	case godef.GoTypeInt:
+		allSQLLines = append(allSQLLines,
+			"\tcase int64:",
+			fmt.Sprintf("\t\tenumValue = %s(value)", enumName),
+			"\tcase []byte:",
+			"\t\treturn e.Scan(string(value))",
+			"\tcase string:",
+			"\t\tparsedValue, parseErr := strconv.ParseInt(value, 10, 64)",
+			"\t\tif parseErr != nil {",
+			fmt.Sprintf("\t\t\treturn %s{Value: value}", errorName),
+			"\t\t}",
+			fmt.Sprintf("\t\tenumValue = %s(parsedValue)", enumName),
+		)
+	case godef.GoTypeFloat:
+		allSQLLines = append(allSQLLines,
+			"\tcase float64:",
+			fmt.Sprintf("\t\tenumValue = %s(value)", enumName),
+			"\tcase int64:",
+			fmt.Sprintf("\t\tenumValue = %s(value)", enumName),
+			"\tcase []byte:",
+			"\t\treturn e.Scan(string(value))",
+			"\tcase string:",
+			"\t\tparsedValue, parseErr := strconv.ParseFloat(value, 64)",
+			"\t\tif parseErr != nil {",
+			fmt.Sprintf("\t\t\treturn %s{Value: value}", errorName),
+			"\t\t}",
+			fmt.Sprintf("\t\tenumValue = %s(parsedValue)", enumName),
+		)
+	}
+
+	allSQLLines = append(allSQLLines,
+		"\tdefault:",
+		fmt.Sprintf("\t\treturn %s{Value: src}", errorName),
+		"\t}",
+		"\tif !enumValue.IsValid() {",
+		fmt.Sprintf("\t\treturn %s{Value: %s(enumValue)}", errorName, baseTypeName),
+		"\t}",
+		"\t*e = enumValue",
+		"\treturn nil",
+		"}",
+		"",
+	)
+	return allSQLLines
+}
+
+// getEnumStringMethodLines returns a String method that maps each value to its entry name.
+// Entries sharing a value are rendered once (first entry wins), since duplicate switch cases do not compile.
+func (w *MorpheEnumFileWriter) getEnumStringMethodLines(enumDefinition *godef.Enum) []string {
+	enumName := enumDefinition.Name
+	methodLines := []string{
+		fmt.Sprintf("// String returns the entry name of the %s value.", enumName),
+		fmt.Sprintf("func (e %s) String() string {", enumName),
+		"\tswitch e {",
+	}
+
+	renderedValues := map[string]any{}
+	for _, enumEntry := range enumDefinition.Entries {
+		entryValue := w.formatEnumValue(enumEntry.Value)
+		if _, isRendered := renderedValues[entryValue]; isRendered {
+			continue
+		}
+		renderedValues[entryValue] = nil
+
+		methodLines = append(methodLines,
+			fmt.Sprintf("\tcase %s:", strcase.ToPascalCase(enumEntry.Name)),
+			fmt.Sprintf("\t\treturn %q", strings.TrimPrefix(enumEntry.Name, enumName)),
+		)
+	}
+
+	methodLines = append(methodLines,
+		"\t}",
+		fmt.Sprintf("\treturn fmt.Sprintf(\"%s(%%v)\", %s(e))", enumName, enumDefinition.Type.BaseType.GetSyntaxLocal()),
+		"}",
+	)
+	return methodLines
+}
+
 func (w *MorpheEnumFileWriter) formatEnumValue(value any) string {
 	switch typedValue := value.(type) {
 	case string:
@@ -63,3 +462,7 @@ func (w *MorpheEnumFileWriter) formatEnumValue(value any) string {
 		return fmt.Sprintf("%v", typedValue)
 	}
 }
+
+func getInvalidEnumErrorName(enumName string) string {
+	return "Invalid" + enumName + "Error"
+}
@@ -0,0 +1,326 @@
+package compile_test
+
+import (
+	"testing"
+
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/write"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+	"github.com/stretchr/testify/suite"
+)
+
+type MorpheEnumFileWriterTestSuite struct {
+	suite.Suite
+}
+
+func TestMorpheEnumFileWriterTestSuite(t *testing.T) {
+	suite.Run(t, new(MorpheEnumFileWriterTestSuite))
+}
+
+func (suite *MorpheEnumFileWriterTestSuite) TestWriteEnum_Integer() {
+	outputFS := &gofile.MemFS{}
+	writer := &compile.MorpheEnumFileWriter{
+		TargetDirPath: "enums",
+		OutputFS:      outputFS,
+	}
+
+	enumDefinition := &godef.Enum{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/enums",
+			Name: "enums",
+		},
+		Name: "Priority",
+		Type: godef.GoTypeDerived{
+			PackagePath: "github.com/kalo-build/project/domain/enums",
+			Name:        "Priority",
+			BaseType:    godef.GoTypeInt,
+		},
+		Entries: []godef.EnumEntry{
+			{Name: "PriorityHigh", Value: 2},
+			{Name: "PriorityLow", Value: 0},
+			{Name: "PriorityNone", Value: 0},
+		},
+	}
+
+	enumContents, writeErr := writer.WriteEnum(enumDefinition)
+
+	suite.Nil(writeErr)
+	suite.Equal(`// Code generated by plugin-morphe-go-struct. DO NOT EDIT.
+
+package enums
+
+import (
+	"fmt"
+)
+
+type Priority int
+
+const (
+	PriorityHigh Priority = 2
+	PriorityLow  Priority = 0
+	PriorityNone Priority = 0
+)
+
+// AllPriorityValues returns all declared Priority values.
+func AllPriorityValues() []Priority {
+	return []Priority{
+		PriorityHigh,
+		PriorityLow,
+		PriorityNone,
+	}
+}
+
+// IsValid returns true if the value is one of the declared Priority values.
+func (e Priority) IsValid() bool {
+	for _, enumValue := range AllPriorityValues() {
+		if e == enumValue {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the entry name of the Priority value.
+func (e Priority) String() string {
+	switch e {
+	case PriorityHigh:
+		return "High"
+	case PriorityLow:
+		return "Low"
+	}
+	return fmt.Sprintf("Priority(%v)", int(e))
+}
+
+// ParsePriority returns the Priority with the given entry name, or an error if no such entry is declared.
+func ParsePriority(name string) (Priority, error) {
+	switch name {
+	case "High":
+		return PriorityHigh, nil
+	case "Low":
+		return PriorityLow, nil
+	case "None":
+		return PriorityNone, nil
+	}
+	return 0, fmt.Errorf("invalid Priority name: %#v", name)
+}
+
+// PriorityFromValue returns the Priority with the given value, or an error if the value is not declared.
+func PriorityFromValue(value int) (Priority, error) {
+	enumValue := Priority(value)
+	if !enumValue.IsValid() {
+		return 0, fmt.Errorf("invalid Priority value: %#v", value)
+	}
+	return enumValue, nil
+}
+`, string(enumContents))
+}
+
+func (suite *MorpheEnumFileWriterTestSuite) TestWriteEnum_Marshalling() {
+	outputFS := &gofile.MemFS{}
+	writer := &compile.MorpheEnumFileWriter{
+		TargetDirPath: "enums",
+		OutputFS:      outputFS,
+	}
+	writer.SetEnumsConfig(cfg.MorpheEnumsConfig{
+		TextMarshalling: true,
+		JSONMarshalling: true,
+		SQLMarshalling:  true,
+	})
+
+	enumDefinition := &godef.Enum{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/enums",
+			Name: "enums",
+		},
+		Name: "Color",
+		Type: godef.GoTypeDerived{
+			PackagePath: "github.com/kalo-build/project/domain/enums",
+			Name:        "Color",
+			BaseType:    godef.GoTypeString,
+		},
+		Entries: []godef.EnumEntry{
+			{Name: "ColorRed", Value: "red"},
+		},
+	}
+
+	enumContents, writeErr := writer.WriteEnum(enumDefinition)
+
+	suite.Nil(writeErr)
+	suite.Equal(`// Code generated by plugin-morphe-go-struct. DO NOT EDIT.
+
+package enums
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type Color string
+
+const (
+	ColorRed Color = "red"
+)
+
+// AllColorValues returns all declared Color values.
+func AllColorValues() []Color {
+	return []Color{
+		ColorRed,
+	}
+}
+
+// IsValid returns true if the value is one of the declared Color values.
+func (e Color) IsValid() bool {
+	for _, enumValue := range AllColorValues() {
+		if e == enumValue {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseColor returns the Color with the given entry name, or an error if no such entry is declared.
+func ParseColor(name string) (Color, error) {
+	switch name {
+	case "Red":
+		return ColorRed, nil
+	}
+	return "", InvalidColorError{Value: name}
+}
+
+// ColorFromValue returns the Color with the given value, or an error if the value is not declared.
+func ColorFromValue(value string) (Color, error) {
+	enumValue := Color(value)
+	if !enumValue.IsValid() {
+		return "", InvalidColorError{Value: value}
+	}
+	return enumValue, nil
+}
+
+// InvalidColorError is returned when a value is not one of the declared Color values.
+type InvalidColorError struct {
+	Value any
+}
+
+func (e InvalidColorError) Error() string {
+	return fmt.Sprintf("invalid Color value: %#v", e.Value)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (e Color) MarshalText() ([]byte, error) {
+	if !e.IsValid() {
+		return nil, InvalidColorError{Value: string(e)}
+	}
+	return []byte(e), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (e *Color) UnmarshalText(text []byte) error {
+	enumValue, fromValueErr := ColorFromValue(string(text))
+	if fromValueErr != nil {
+		return fromValueErr
+	}
+	*e = enumValue
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e Color) MarshalJSON() ([]byte, error) {
+	if !e.IsValid() {
+		return nil, InvalidColorError{Value: string(e)}
+	}
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Color) UnmarshalJSON(data []byte) error {
+	var value string
+	unmarshalErr := json.Unmarshal(data, &value)
+	if unmarshalErr != nil {
+		return unmarshalErr
+	}
+	enumValue := Color(value)
+	if !enumValue.IsValid() {
+		return InvalidColorError{Value: value}
+	}
+	*e = enumValue
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (e Color) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, InvalidColorError{Value: string(e)}
+	}
+	return string(e), nil
+}
+
+// Scan implements sql.Scanner.
+func (e *Color) Scan(src any) error {
+	var enumValue Color
+	switch value := src.(type) {
+	case string:
+		enumValue = Color(value)
+	case []byte:
+		enumValue = Color(value)
+	default:
+		return InvalidColorError{Value: src}
+	}
+	if !enumValue.IsValid() {
+		return InvalidColorError{Value: string(enumValue)}
+	}
+	*e = enumValue
+	return nil
+}
+`, string(enumContents))
+}
+
+func (suite *MorpheEnumFileWriterTestSuite) TestWriteEnum_Docs() {
+	outputFS := &gofile.MemFS{}
+	writer := &compile.MorpheEnumFileWriter{
+		TargetDirPath: "enums",
+		OutputFS:      outputFS,
+	}
+	writer.SetEnumDocs(map[string]write.EnumDoc{
+		"Priority": {
+			Lines: []string{"Priority orders the tasks.", "", "Tasks without priority come last."},
+			EntryLines: map[string][]string{
+				"PriorityHigh": {"PriorityHigh is the Priority value 2."},
+			},
+		},
+	})
+
+	enumDefinition := &godef.Enum{
+		Package: godef.Package{
+			Path: "github.com/kalo-build/project/domain/enums",
+			Name: "enums",
+		},
+		Name: "Priority",
+		Type: godef.GoTypeDerived{
+			PackagePath: "github.com/kalo-build/project/domain/enums",
+			Name:        "Priority",
+			BaseType:    godef.GoTypeInt,
+		},
+		Entries: []godef.EnumEntry{
+			{Name: "PriorityHigh", Value: 2},
+			{Name: "PriorityLow", Value: 0},
+		},
+	}
+
+	enumContents, writeErr := writer.WriteEnum(enumDefinition)
+
+	suite.Nil(writeErr)
+	suite.Contains(string(enumContents), `// Priority orders the tasks.
+//
+// Tasks without priority come last.
+type Priority int
+
+const (
+	// PriorityHigh is the Priority value 2.
+	PriorityHigh Priority = 2
+	PriorityLow  Priority = 0
+)
+`)
+}
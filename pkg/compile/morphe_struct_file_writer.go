@@ -6,12 +6,24 @@ import (
 
 	"github.com/kalo-build/go-util/core"
 	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/write"
 	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
 )
 
 type MorpheStructFileWriter struct {
 	Type          MorpheStructType
 	TargetDirPath string
+
+	// OutputFS is the filesystem definition files are written to, the local filesystem is used if nil
+	OutputFS gofile.OutputFS
+
+	// CheckOnly compares the rendered definitions with the existing files in the target directory instead of writing them
+	CheckOnly bool
+
+	// StructDocs holds the doc comments rendered on the structs (struct name -> doc), it is set from the compile config before writing
+	StructDocs map[string]write.StructDoc
+
+	definitionFileTracker
 }
 
 func (w *MorpheStructFileWriter) WriteStruct(structDefinition *godef.Struct) ([]byte, error) {
@@ -25,11 +37,39 @@ func (w *MorpheStructFileWriter) WriteStruct(structDefinition *godef.Struct) ([]
 		return nil, structContentsErr
 	}
 
-	return gofile.WriteGoDefinitionFile(w.TargetDirPath, structDefinition.Name, structFileContents)
+	return w.writeDefinitionFile(w.OutputFS, w.TargetDirPath, w.CheckOnly, structDefinition.Name, structFileContents)
+}
+
+// WriteSource writes a pre-rendered definition into the target directory, next to the struct definitions.
+func (w *MorpheStructFileWriter) WriteSource(definitionName string, fileContents string) ([]byte, error) {
+	return w.writeDefinitionFile(w.OutputFS, w.TargetDirPath, w.CheckOnly, definitionName, fileContents)
+}
+
+// SetStructDocs sets the doc comments used for all following writes.
+func (w *MorpheStructFileWriter) SetStructDocs(allStructDocs map[string]write.StructDoc) {
+	w.StructDocs = allStructDocs
+}
+
+// SetCheckOnly enables or disables check mode.
+func (w *MorpheStructFileWriter) SetCheckOnly(checkOnly bool) {
+	w.CheckOnly = checkOnly
+}
+
+// GetCheckResult returns all differences found in check mode since the last call, including generated files that were not rendered again.
+func (w *MorpheStructFileWriter) GetCheckResult() (gofile.CheckResult, error) {
+	return w.getCheckResult(w.OutputFS, w.TargetDirPath)
+}
+
+// RemoveStaleFiles deletes all generated files in the target directory that were not written since the last cleanup.
+func (w *MorpheStructFileWriter) RemoveStaleFiles() ([]string, error) {
+	return w.removeStaleFiles(w.OutputFS, w.TargetDirPath)
 }
 
 func (w *MorpheStructFileWriter) getAllStructLines(structDefinition *godef.Struct) ([]string, error) {
-	allStructLines := []string{}
+	allStructLines := []string{
+		gofile.GeneratedFileHeader,
+		"",
+	}
 
 	packageLine := fmt.Sprintf("package %s", structDefinition.Package.Name)
 	allStructLines = append(allStructLines, packageLine)
@@ -83,11 +123,23 @@ func (w *MorpheStructFileWriter) getAllStructImportLines(structDefinition *godef
 }
 
 func (w *MorpheStructFileWriter) getAllStructTypeLines(structDefinition *godef.Struct) ([]string, error) {
-	allTypeLines := []string{
-		fmt.Sprintf("type %s struct {", structDefinition.Name),
+	allTypeLines := []string{}
+
+	idType, hasIDType := findStructIDType(structDefinition)
+	if hasIDType {
+		allTypeLines = append(allTypeLines,
+			fmt.Sprintf("// %s is the primary identifier type of %s.", idType.Name, structDefinition.Name),
+			fmt.Sprintf("type %s %s", idType.Name, idType.BaseType.GetSyntax()),
+			"",
+		)
 	}
 
+	structDoc := w.StructDocs[structDefinition.Name]
+	allTypeLines = append(allTypeLines, getDocCommentLines("", structDoc.Lines)...)
+	allTypeLines = append(allTypeLines, fmt.Sprintf("type %s struct {", structDefinition.Name))
+
 	for _, structField := range structDefinition.Fields {
+		allTypeLines = append(allTypeLines, getDocCommentLines("\t", structDoc.FieldLines[structField.Name])...)
 		structFieldTypeSyntax := structField.Type.GetSyntax()
 		if len(structField.Tags) == 0 {
 			structFieldLine := fmt.Sprintf("\t%s %s", structField.Name, structFieldTypeSyntax)
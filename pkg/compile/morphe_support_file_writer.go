@@ -0,0 +1,36 @@
+package compile
+
+import (
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+)
+
+type MorpheSupportFileWriter struct {
+	TargetDirPath string
+
+	// OutputFS is the filesystem definition files are written to, the local filesystem is used if nil
+	OutputFS gofile.OutputFS
+
+	// CheckOnly compares the rendered definitions with the existing files in the target directory instead of writing them
+	CheckOnly bool
+
+	definitionFileTracker
+}
+
+func (w *MorpheSupportFileWriter) WriteSupport(definitionName string, fileContents string) ([]byte, error) {
+	return w.writeDefinitionFile(w.OutputFS, w.TargetDirPath, w.CheckOnly, definitionName, fileContents)
+}
+
+// SetCheckOnly enables or disables check mode.
+func (w *MorpheSupportFileWriter) SetCheckOnly(checkOnly bool) {
+	w.CheckOnly = checkOnly
+}
+
+// GetCheckResult returns all differences found in check mode since the last call, including generated files that were not rendered again.
+func (w *MorpheSupportFileWriter) GetCheckResult() (gofile.CheckResult, error) {
+	return w.getCheckResult(w.OutputFS, w.TargetDirPath)
+}
+
+// RemoveStaleFiles deletes all generated files in the target directory that were not written since the last cleanup.
+func (w *MorpheSupportFileWriter) RemoveStaleFiles() ([]string, error) {
+	return w.removeStaleFiles(w.OutputFS, w.TargetDirPath)
+}
@@ -0,0 +1,250 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/go-util/inflect"
+	"github.com/kalo-build/go-util/strcase"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/morphe-go/pkg/yamlops"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+)
+
+// ormField describes how a struct field is persisted, the ORM struct tags are derived from it.
+type ormField struct {
+	// NotColumn marks fields that aren't stored in the table of the struct, ie. related ID slices
+	NotColumn     bool
+	PrimaryKey    bool
+	AutoIncrement bool
+	Optional      bool
+	Immutable     bool
+
+	// Relation is set for fields holding related structs
+	Relation *ormRelation
+}
+
+type ormRelationKind string
+
+const (
+	ormRelationBelongsTo  ormRelationKind = "belongs-to"
+	ormRelationHasOne     ormRelationKind = "has-one"
+	ormRelationHasMany    ormRelationKind = "has-many"
+	ormRelationManyToMany ormRelationKind = "many-to-many"
+)
+
+// ormRelation describes a relation field. The foreign key is a field of the struct itself for belongs-to relations
+// and a field of the related struct otherwise.
+type ormRelation struct {
+	Kind       ormRelationKind
+	ForeignKey string
+	References string
+
+	// JoinTable, OwnerName and TargetName describe many-to-many relations
+	JoinTable  string
+	OwnerName  string
+	TargetName string
+
+	// Polymorphic is the polymorphic For* relation of the related struct (ie. "Commentable") and PolymorphicValue the type stored in it
+	Polymorphic      string
+	PolymorphicValue string
+}
+
+// buildORMFieldTags constructs the enabled ORM struct tags (db, gorm, bun) for a field.
+func buildORMFieldTags(config cfg.ORMTagsConfig, fieldName string, field ormField) []string {
+	var tags []string
+	if config.DB {
+		tags = append(tags, fmt.Sprintf("db:\"%s\"", getDBTagValue(config, fieldName, field)))
+	}
+	if config.Gorm {
+		tags = append(tags, fmt.Sprintf("gorm:\"%s\"", getGormTagValue(config, fieldName, field)))
+	}
+	if config.Bun {
+		tags = append(tags, fmt.Sprintf("bun:\"%s\"", getBunTagValue(config, fieldName, field)))
+	}
+	return tags
+}
+
+func getDBTagValue(config cfg.ORMTagsConfig, fieldName string, field ormField) string {
+	if field.NotColumn || field.Relation != nil {
+		return "-"
+	}
+	return config.GetColumnName(fieldName)
+}
+
+func getGormTagValue(config cfg.ORMTagsConfig, fieldName string, field ormField) string {
+	if field.Relation != nil {
+		relation := field.Relation
+		switch {
+		case relation.Kind == ormRelationManyToMany:
+			return "many2many:" + relation.JoinTable
+		case relation.Polymorphic != "":
+			return fmt.Sprintf("polymorphic:%s;polymorphicValue:%s", relation.Polymorphic, relation.PolymorphicValue)
+		default:
+			return fmt.Sprintf("foreignKey:%s;references:%s", relation.ForeignKey, relation.References)
+		}
+	}
+	if field.NotColumn {
+		return "-"
+	}
+
+	options := []string{
+		"column:" + config.GetColumnName(fieldName),
+	}
+	if field.PrimaryKey {
+		options = append(options, "primaryKey")
+	}
+	if field.AutoIncrement {
+		options = append(options, "autoIncrement")
+	}
+	if !field.Optional && !field.PrimaryKey {
+		options = append(options, "not null")
+	}
+	if field.Immutable {
+		options = append(options, "<-:create")
+	}
+	return strings.Join(options, ";")
+}
+
+func getBunTagValue(config cfg.ORMTagsConfig, fieldName string, field ormField) string {
+	if field.Relation != nil {
+		relation := field.Relation
+		switch {
+		case relation.Kind == ormRelationManyToMany:
+			return fmt.Sprintf("m2m:%s,join:%s=%s", relation.JoinTable, relation.OwnerName, relation.TargetName)
+		case relation.Polymorphic != "":
+			return fmt.Sprintf("rel:%s,join:%s=%s,join:type=%s,polymorphic",
+				relation.Kind,
+				config.GetColumnName(relation.References),
+				config.GetColumnName(relation.Polymorphic+"ID"),
+				config.GetColumnName(relation.Polymorphic+"Type"),
+			)
+		case relation.Kind == ormRelationBelongsTo:
+			return fmt.Sprintf("rel:%s,join:%s=%s", relation.Kind, config.GetColumnName(relation.ForeignKey), config.GetColumnName(relation.References))
+		default:
+			return fmt.Sprintf("rel:%s,join:%s=%s", relation.Kind, config.GetColumnName(relation.References), config.GetColumnName(relation.ForeignKey))
+		}
+	}
+	if field.NotColumn {
+		return "-"
+	}
+
+	options := []string{
+		config.GetColumnName(fieldName),
+	}
+	if field.PrimaryKey {
+		options = append(options, "pk")
+	}
+	if field.AutoIncrement {
+		options = append(options, "autoincrement")
+	}
+	if !field.Optional && !field.PrimaryKey {
+		options = append(options, "notnull")
+	}
+	return strings.Join(options, ",")
+}
+
+// getORMDirectField describes a direct field of a model or entity.
+func getORMDirectField(fieldName string, fieldType yaml.ModelFieldType, attributes []string, primaryFieldNames []string) ormField {
+	return ormField{
+		PrimaryKey:    slices.Contains(primaryFieldNames, fieldName),
+		AutoIncrement: fieldType == yaml.ModelFieldTypeAutoIncrement,
+		Optional:      hasAttribute(attributes, "optional"),
+		Immutable:     hasAttribute(attributes, "immutable"),
+	}
+}
+
+// getORMRelatedIDField describes the related ID field of a relation, which is only a column for For* relations to a single struct.
+func getORMRelatedIDField(relationDef yaml.ModelRelation) ormField {
+	return ormField{
+		NotColumn: !yamlops.IsRelationFor(relationDef.Type) || yamlops.IsRelationMany(relationDef.Type),
+		Optional:  hasAttribute(relationDef.Attributes, "optional"),
+	}
+}
+
+// getORMPolyField describes the type and ID fields of a polymorphic For* relation.
+func getORMPolyField(relationDef yaml.ModelRelation) ormField {
+	return ormField{
+		Optional: hasAttribute(relationDef.Attributes, "optional"),
+	}
+}
+
+// getORMRelatedField describes the field holding the related struct(s) of a (non polymorphic For*) relation. Foreign keys
+// on the related struct are derived from its For* relation back to the owner (or the path alias, ie. "Person.WorkProject").
+func getORMRelatedField(ownerName string, ownerPrimaryIDFieldName string, relationName string, relationDef yaml.ModelRelation, relatedIDFieldName string, targetName string, targetPrimaryIDFieldName string, targetRelations map[string]yaml.ModelRelation) ormField {
+	if yamlops.IsRelationFor(relationDef.Type) && yamlops.IsRelationMany(relationDef.Type) {
+		return ormField{
+			Relation: &ormRelation{
+				Kind:       ormRelationManyToMany,
+				JoinTable:  strcase.ToSnakeCaseLower(ownerName) + "_" + strcase.ToSnakeCaseLower(inflect.Plural(relationName)),
+				OwnerName:  ownerName,
+				TargetName: targetName,
+			},
+		}
+	}
+	if yamlops.IsRelationFor(relationDef.Type) {
+		return ormField{
+			Relation: &ormRelation{
+				Kind:       ormRelationBelongsTo,
+				ForeignKey: relatedIDFieldName,
+				References: targetPrimaryIDFieldName,
+			},
+		}
+	}
+
+	relationKind := ormRelationHasOne
+	if yamlops.IsRelationMany(relationDef.Type) {
+		relationKind = ormRelationHasMany
+	}
+
+	polymorphicName := getORMPolymorphicName(ownerName, relationDef, targetRelations)
+	if yamlops.IsRelationPoly(relationDef.Type) && polymorphicName != "" {
+		return ormField{
+			Relation: &ormRelation{
+				Kind:             relationKind,
+				References:       ownerPrimaryIDFieldName,
+				Polymorphic:      polymorphicName,
+				PolymorphicValue: ownerName,
+			},
+		}
+	}
+
+	return ormField{
+		Relation: &ormRelation{
+			Kind:       relationKind,
+			ForeignKey: getORMBackReferenceName(ownerName, relationDef, targetRelations) + ownerPrimaryIDFieldName,
+			References: ownerPrimaryIDFieldName,
+		},
+	}
+}
+
+func getORMBackReferenceName(ownerName string, relationDef yaml.ModelRelation, targetRelations map[string]yaml.ModelRelation) string {
+	if aliasParts := strings.Split(relationDef.Aliased, "."); len(aliasParts) > 1 {
+		return aliasParts[1]
+	}
+	for _, targetRelationName := range core.MapKeysSorted(targetRelations) {
+		targetRelation := targetRelations[targetRelationName]
+		if yamlops.IsRelationPoly(targetRelation.Type) || !yamlops.IsRelationFor(targetRelation.Type) {
+			continue
+		}
+		if yamlops.GetRelationTargetName(targetRelationName, targetRelation.Aliased) == ownerName {
+			return targetRelationName
+		}
+	}
+	return ownerName
+}
+
+func getORMPolymorphicName(ownerName string, relationDef yaml.ModelRelation, targetRelations map[string]yaml.ModelRelation) string {
+	if relationDef.Through != "" {
+		return relationDef.Through
+	}
+	for _, targetRelationName := range core.MapKeysSorted(targetRelations) {
+		targetRelation := targetRelations[targetRelationName]
+		if isModelPolyForRelation(targetRelation) && slices.Contains(targetRelation.For, ownerName) {
+			return targetRelationName
+		}
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+package compile
+
+import (
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/write"
+)
+
+// RemoveAllStaleFiles removes generated files that were not written during the current run from the output of all configured writers.
+// Writers that do not implement write.StaleFileRemover are skipped.
+func RemoveAllStaleFiles(config MorpheCompileConfig) ([]string, error) {
+	allRemovedFilePaths := []string{}
+	for _, writer := range config.allWriters() {
+		remover, isRemover := writer.(write.StaleFileRemover)
+		if !isRemover {
+			continue
+		}
+		removedFilePaths, removeErr := remover.RemoveStaleFiles()
+		if removeErr != nil {
+			return nil, removeErr
+		}
+		allRemovedFilePaths = append(allRemovedFilePaths, removedFilePaths...)
+	}
+	return allRemovedFilePaths, nil
+}
+
+// resetAllTrackedFiles resets the files tracked by all configured writers at the start of a compilation.
+// Writers that do not implement write.TrackedFileResetter are skipped.
+func resetAllTrackedFiles(config MorpheCompileConfig) {
+	for _, writer := range config.allWriters() {
+		resetter, isResetter := writer.(write.TrackedFileResetter)
+		if !isResetter {
+			continue
+		}
+		resetter.ResetTrackedFiles()
+	}
+}
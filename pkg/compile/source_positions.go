@@ -0,0 +1,151 @@
+package compile
+
+import (
+	"fmt"
+
+	"github.com/kalo-build/morphe-go/pkg/registry"
+	rcfg "github.com/kalo-build/morphe-go/pkg/registry/cfg"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+// SourcePosition is a location in a YAML registry file. Lines and columns start at 1, zero means unknown.
+type SourcePosition struct {
+	FilePath string
+	Line     int
+	Column   int
+}
+
+func (p SourcePosition) IsEmpty() bool {
+	return p.FilePath == ""
+}
+
+// String returns the position in the "file:line:col" form understood by editors.
+func (p SourcePosition) String() string {
+	if p.Line == 0 {
+		return p.FilePath
+	}
+	return fmt.Sprintf("%s:%d:%d", p.FilePath, p.Line, p.Column)
+}
+
+// DefinitionPositions holds the positions of a YAML definition (its "name" key) and of the keys of its fields, relations,
+// identifiers and enum entries.
+type DefinitionPositions struct {
+	Definition  SourcePosition
+	Fields      map[string]SourcePosition
+	Related     map[string]SourcePosition
+	Identifiers map[string]SourcePosition
+	Entries     map[string]SourcePosition
+}
+
+// SourcePositions holds the positions of all definitions by name.
+type SourcePositions struct {
+	Models     map[string]DefinitionPositions
+	Entities   map[string]DefinitionPositions
+	Structures map[string]DefinitionPositions
+	Enums      map[string]DefinitionPositions
+}
+
+// positionSource keeps the nodes of a YAML definition, since the registry definitions hold neither file paths nor positions.
+type positionSource struct {
+	Name        yaml3.Node `yaml:"name"`
+	Fields      yaml3.Node `yaml:"fields"`
+	Related     yaml3.Node `yaml:"related"`
+	Identifiers yaml3.Node `yaml:"identifiers"`
+	Entries     yaml3.Node `yaml:"entries"`
+}
+
+// LoadSourcePositions reads the positions of all definitions from the YAML registry directories.
+func LoadSourcePositions(config rcfg.MorpheLoadRegistryConfig) (SourcePositions, error) {
+	allModelPositions, modelsErr := loadSourcePositionsFromDirectory(config.RegistryModelsDirPath, registry.ModelFileSuffix)
+	if modelsErr != nil {
+		return SourcePositions{}, modelsErr
+	}
+	allEntityPositions, entitiesErr := loadSourcePositionsFromDirectory(config.RegistryEntitiesDirPath, registry.EntityFileSuffix)
+	if entitiesErr != nil {
+		return SourcePositions{}, entitiesErr
+	}
+	allStructurePositions, structuresErr := loadSourcePositionsFromDirectory(config.RegistryStructuresDirPath, registry.StructureFileSuffix)
+	if structuresErr != nil {
+		return SourcePositions{}, structuresErr
+	}
+	allEnumPositions, enumsErr := loadSourcePositionsFromDirectory(config.RegistryEnumsDirPath, registry.EnumFileSuffix)
+	if enumsErr != nil {
+		return SourcePositions{}, enumsErr
+	}
+
+	return SourcePositions{
+		Models:     allModelPositions,
+		Entities:   allEntityPositions,
+		Structures: allStructurePositions,
+		Enums:      allEnumPositions,
+	}, nil
+}
+
+func loadSourcePositionsFromDirectory(dirPath string, fileSuffix string) (map[string]DefinitionPositions, error) {
+	allSources, unmarshalErr := unmarshalAllRegistrySources[positionSource](dirPath, fileSuffix)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	allPositions := map[string]DefinitionPositions{}
+	for filePath, source := range allSources {
+		allPositions[source.Name.Value] = DefinitionPositions{
+			Definition:  getNodePosition(filePath, &source.Name),
+			Fields:      getMappingKeyPositions(filePath, source.Fields),
+			Related:     getMappingKeyPositions(filePath, source.Related),
+			Identifiers: getMappingKeyPositions(filePath, source.Identifiers),
+			Entries:     getMappingKeyPositions(filePath, source.Entries),
+		}
+	}
+	return allPositions, nil
+}
+
+func getNodePosition(filePath string, node *yaml3.Node) SourcePosition {
+	return SourcePosition{
+		FilePath: filePath,
+		Line:     node.Line,
+		Column:   node.Column,
+	}
+}
+
+func getMappingKeyPositions(filePath string, node yaml3.Node) map[string]SourcePosition {
+	allPositions := map[string]SourcePosition{}
+	if node.Kind != yaml3.MappingNode {
+		return allPositions
+	}
+	// Mapping node contents alternate between keys and values
+	for keyIdx := 0; keyIdx < len(node.Content); keyIdx += 2 {
+		keyNode := node.Content[keyIdx]
+		allPositions[keyNode.Value] = getNodePosition(filePath, keyNode)
+	}
+	return allPositions
+}
+
+// GetPosition returns the position of a field, relation, identifier or enum entry of a definition, falling back to the
+// position of the definition itself.
+func (positions SourcePositions) GetPosition(kind CompileErrorKind, definitionName string, fieldName string) SourcePosition {
+	allDefinitionPositions := map[CompileErrorKind]map[string]DefinitionPositions{
+		CompileErrorKindEnum:      positions.Enums,
+		CompileErrorKindModel:     positions.Models,
+		CompileErrorKindStructure: positions.Structures,
+		CompileErrorKindEntity:    positions.Entities,
+	}
+	definitionPositions, hasDefinition := allDefinitionPositions[kind][definitionName]
+	if !hasDefinition {
+		return SourcePosition{}
+	}
+	if fieldName == "" {
+		return definitionPositions.Definition
+	}
+	for _, keyPositions := range []map[string]SourcePosition{
+		definitionPositions.Fields,
+		definitionPositions.Related,
+		definitionPositions.Entries,
+		definitionPositions.Identifiers,
+	} {
+		if keyPosition, hasKey := keyPositions[fieldName]; hasKey {
+			return keyPosition
+		}
+	}
+	return definitionPositions.Definition
+}
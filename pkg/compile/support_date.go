@@ -0,0 +1,128 @@
+package compile
+
+// supportDateSource is the civil Date type generated into the support package if cfg.MorpheSupportConfig.CivilDate is enabled.
+const supportDateSource = `import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Date is a calendar date without time of day or location, serialized as "YYYY-MM-DD". The zero Date is serialized
+// as an empty text, JSON null and SQL NULL.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of the time in its location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// ParseDate parses a date in the "YYYY-MM-DD" format.
+func ParseDate(value string) (Date, error) {
+	parsedTime, parseErr := time.Parse(dateLayout, value)
+	if parseErr != nil {
+		return Date{}, parseErr
+	}
+	return DateOf(parsedTime), nil
+}
+
+// String returns the date in the "YYYY-MM-DD" format, or "" for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsZero returns true if the date is the zero value.
+func (d Date) IsZero() bool {
+	return d.Year == 0 && d.Month == 0 && d.Day == 0
+}
+
+// In returns the time at midnight of the date in the location.
+func (d Date) In(location *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, location)
+}
+
+// Before returns true if the date is before the other date.
+func (d Date) Before(other Date) bool {
+	return d.In(time.UTC).Before(other.In(time.UTC))
+}
+
+// After returns true if the date is after the other date.
+func (d Date) After(other Date) bool {
+	return d.In(time.UTC).After(other.In(time.UTC))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, an empty text is the zero Date.
+func (d *Date) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Date{}
+		return nil
+	}
+	parsedDate, parseErr := ParseDate(string(text))
+	if parseErr != nil {
+		return parseErr
+	}
+	*d = parsedDate
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, the zero Date is marshalled as null.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, null leaves the date unchanged.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var value string
+	unmarshalErr := json.Unmarshal(data, &value)
+	if unmarshalErr != nil {
+		return unmarshalErr
+	}
+	return d.UnmarshalText([]byte(value))
+}
+
+// Value implements driver.Valuer, the date is stored as midnight UTC and the zero Date as NULL.
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.In(time.UTC), nil
+}
+
+// Scan implements sql.Scanner.
+func (d *Date) Scan(src any) error {
+	switch value := src.(type) {
+	case time.Time:
+		*d = DateOf(value)
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(value))
+	case []byte:
+		return d.UnmarshalText(value)
+	case nil:
+		*d = Date{}
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into Date", src)
+}
+`
@@ -0,0 +1,110 @@
+package compile
+
+import "strings"
+
+// supportRedactedSource is shared by all redacting wrapper types generated if cfg.MorpheSupportConfig.RedactSecrets is enabled.
+const supportRedactedSource = `import "errors"
+
+// Redacted replaces secret values whenever they are formatted or marshalled to JSON.
+const Redacted = "[REDACTED]"
+
+// ErrRedacted is returned when unmarshalling Redacted into a secret, since the raw value was lost when it was marshalled.
+var ErrRedacted = errors.New("cannot unmarshal redacted secret, the raw value is not marshalled")
+`
+
+// supportSecretSource is the redacting wrapper type generated for Protected and Sealed fields, see getSupportSecretSource.
+const supportSecretSource = `import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// SecretType holds the value of a SecretType field (SecretExample). The value is redacted whenever it is
+// formatted or marshalled to JSON, use Raw to access it. Marshalled JSON can therefore not be unmarshalled again:
+// UnmarshalJSON returns ErrRedacted for the Redacted placeholder.
+type SecretType struct {
+	value string
+}
+
+// NewSecretType wraps the raw value.
+func NewSecretType(value string) SecretType {
+	return SecretType{value: value}
+}
+
+// Raw returns the unredacted value.
+func (s SecretType) Raw() string {
+	return s.value
+}
+
+// IsZero returns true if the value is empty.
+func (s SecretType) IsZero() bool {
+	return s.value == ""
+}
+
+// String implements fmt.Stringer and always returns Redacted.
+func (s SecretType) String() string {
+	return Redacted
+}
+
+// GoString implements fmt.GoStringer and always returns Redacted.
+func (s SecretType) GoString() string {
+	return Redacted
+}
+
+// Format implements fmt.Formatter and prints Redacted for all verbs.
+func (s SecretType) Format(state fmt.State, verb rune) {
+	state.Write([]byte(Redacted))
+}
+
+// MarshalJSON implements json.Marshaler and always marshals Redacted.
+func (s SecretType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Redacted)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the raw value is read from a JSON string and null leaves it unchanged.
+// The Redacted placeholder is rejected with ErrRedacted instead of becoming the raw value.
+func (s *SecretType) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var value string
+	unmarshalErr := json.Unmarshal(data, &value)
+	if unmarshalErr != nil {
+		return unmarshalErr
+	}
+	if value == Redacted {
+		return ErrRedacted
+	}
+	s.value = value
+	return nil
+}
+
+// Value implements driver.Valuer, the raw value is stored.
+func (s SecretType) Value() (driver.Value, error) {
+	return s.value, nil
+}
+
+// Scan implements sql.Scanner.
+func (s *SecretType) Scan(src any) error {
+	switch value := src.(type) {
+	case string:
+		s.value = value
+		return nil
+	case []byte:
+		s.value = string(value)
+		return nil
+	case nil:
+		s.value = ""
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into SecretType", src)
+}
+`
+
+// getSupportSecretSource returns the redacting wrapper type source for the Morphe field type (Protected, Sealed).
+func getSupportSecretSource(typeName string, example string) string {
+	return strings.NewReplacer(
+		"SecretType", typeName,
+		"SecretExample", example,
+	).Replace(supportSecretSource)
+}
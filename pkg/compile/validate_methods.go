@@ -0,0 +1,300 @@
+package compile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/morphe-go/pkg/registry"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/morphe-go/pkg/yamlops"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+)
+
+// validationErrorsDefinitionName is the definition (and file) name of the ValidationErrors type declared next to the generated Validate methods.
+const validationErrorsDefinitionName = "ValidationErrors"
+
+// validationErrorsSource declares the errors returned by the generated Validate methods, see getValidationErrorsFileContents.
+const validationErrorsSource = `import "strings"
+
+// ValidationError is a single invalid field. The path is relative to the validated struct, ie. "Address.Country".
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors lists all invalid fields of a struct, it is returned by the generated Validate methods.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for errIdx, err := range e {
+		messages[errIdx] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// appendNested adds the errors of a nested struct, prefixing their paths with the path of the nested struct.
+func (e ValidationErrors) appendNested(path string, err error) ValidationErrors {
+	if err == nil {
+		return e
+	}
+	nestedErrs, isValidationErrs := err.(ValidationErrors)
+	if !isValidationErrs {
+		return append(e, ValidationError{Path: path, Message: err.Error()})
+	}
+	for _, nestedErr := range nestedErrs {
+		e = append(e, ValidationError{Path: path + "." + nestedErr.Path, Message: nestedErr.Message})
+	}
+	return e
+}
+
+// orNil returns nil if there are no errors, so that a valid struct returns an untyped nil error.
+func (e ValidationErrors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+`
+
+type validateCheckKind string
+
+const (
+	// validateCheckEnum checks that an enum field holds a declared value
+	validateCheckEnum validateCheckKind = "enum"
+	// validateCheckOneOf checks that the type field of an untyped polymorphic relation holds one of the linked names
+	validateCheckOneOf validateCheckKind = "oneOf"
+	// validateCheckRequired checks that a related struct pointer is set
+	validateCheckRequired validateCheckKind = "required"
+	// validateCheckNested validates a nested structure
+	validateCheckNested validateCheckKind = "nested"
+)
+
+// validateCheck is a single check of a generated Validate method.
+type validateCheck struct {
+	Kind      validateCheckKind
+	FieldName string
+
+	// Pointer marks optional pointer fields, which are only checked if set
+	Pointer bool
+	// Zero is the zero value literal of optional non-pointer fields (ie. `""`), which are only checked if set
+	Zero string
+
+	// OneOf lists the allowed values of validateCheckOneOf checks
+	OneOf []string
+}
+
+func getValidationErrorsFileContents(structPackage godef.Package) string {
+	return fmt.Sprintf("%s\n\npackage %s\n\n%s", gofile.GeneratedFileHeader, structPackage.Name, validationErrorsSource)
+}
+
+// getValidateMethod builds the Validate method of a struct, which runs all checks and collects the invalid fields in ValidationErrors.
+func getValidateMethod(structPackage godef.Package, receiverName string, structName string, allChecks []validateCheck) godef.StructMethod {
+	bodyLines := []string{
+		"\tvar errs ValidationErrors",
+	}
+	for _, check := range allChecks {
+		bodyLines = append(bodyLines, getValidateCheckLines(receiverName, check)...)
+	}
+	bodyLines = append(bodyLines, "\treturn errs.orNil()")
+
+	return godef.StructMethod{
+		ReceiverName: receiverName,
+		ReceiverType: godef.GoTypeStruct{
+			PackagePath: structPackage.Path,
+			Name:        structName,
+		},
+		Name:        "Validate",
+		ReturnTypes: []godef.GoType{godef.GoTypeError},
+		BodyLines:   bodyLines,
+	}
+}
+
+func getValidateCheckLines(receiverName string, check validateCheck) []string {
+	fieldRef := receiverName + "." + check.FieldName
+
+	switch check.Kind {
+	case validateCheckNested:
+		nestedLine := fmt.Sprintf("errs = errs.appendNested(%q, %s.Validate())", check.FieldName, fieldRef)
+		if !check.Pointer {
+			return []string{"\t" + nestedLine}
+		}
+		return []string{
+			fmt.Sprintf("\tif %s != nil {", fieldRef),
+			"\t\t" + nestedLine,
+			"\t}",
+		}
+	case validateCheckRequired:
+		return getValidateErrorLines(fmt.Sprintf("%s == nil", fieldRef), check.FieldName, "is required")
+	case validateCheckOneOf:
+		valueRef := fieldRef
+		if check.Pointer {
+			valueRef = "*" + fieldRef
+		}
+		conditions := []string{}
+		for _, value := range check.OneOf {
+			conditions = append(conditions, fmt.Sprintf("%s != %q", valueRef, value))
+		}
+		return getValidateErrorLines(getValidateSetCondition(fieldRef, check, strings.Join(conditions, " && ")), check.FieldName, "must be one of: "+strings.Join(check.OneOf, ", "))
+	default:
+		condition := fmt.Sprintf("!%s.IsValid()", fieldRef)
+		return getValidateErrorLines(getValidateSetCondition(fieldRef, check, condition), check.FieldName, "invalid value")
+	}
+}
+
+// getValidateSetCondition restricts the condition of optional fields to set values.
+func getValidateSetCondition(fieldRef string, check validateCheck, condition string) string {
+	if check.Pointer {
+		return fmt.Sprintf("%s != nil && %s", fieldRef, condition)
+	}
+	if check.Zero != "" {
+		return fmt.Sprintf("%s != %s && %s", fieldRef, check.Zero, condition)
+	}
+	return condition
+}
+
+func getValidateErrorLines(condition string, fieldName string, message string) []string {
+	return []string{
+		fmt.Sprintf("\tif %s {", condition),
+		fmt.Sprintf("\t\terrs = append(errs, ValidationError{Path: %q, Message: %q})", fieldName, message),
+		"\t}",
+	}
+}
+
+// getModelValidateChecks checks all enum fields, the type fields of polymorphic For* relations and non-optional ForOne relations of a model.
+func getModelValidateChecks(config cfg.MorpheConfig, allEnums map[string]yaml.Enum, model yaml.Model) []validateCheck {
+	allChecks := []validateCheck{}
+	for _, fieldName := range core.MapKeysSorted(model.Fields) {
+		fieldDef := model.Fields[fieldName]
+		_, hasOverride := config.MorpheTypeOverridesConfig.GetModelFieldOverride(model.Name, fieldName, string(fieldDef.Type))
+		if _, isEnum := allEnums[string(fieldDef.Type)]; !isEnum || hasOverride {
+			continue
+		}
+		allChecks = append(allChecks, validateCheck{
+			Kind:      validateCheckEnum,
+			FieldName: fieldName,
+			Pointer:   hasAttribute(fieldDef.Attributes, "optional"),
+		})
+	}
+
+	for _, relationName := range core.MapKeysSorted(model.Related) {
+		relationDef := model.Related[relationName]
+		if !isModelPolyForRelation(relationDef) {
+			allChecks = append(allChecks, getRelationValidateChecks(relationName, relationDef)...)
+			continue
+		}
+
+		// The type fields of models are never pointers
+		polyCheck := validateCheck{
+			Kind:      validateCheckOneOf,
+			FieldName: relationName + "Type",
+			OneOf:     getSortedPolyTargetNames(relationDef),
+		}
+		if config.MorpheModelsConfig.TypedPolyRelations {
+			polyCheck.Kind = validateCheckEnum
+		}
+		if hasAttribute(relationDef.Attributes, "optional") {
+			polyCheck.Zero = `""`
+		}
+		allChecks = append(allChecks, polyCheck)
+	}
+	return allChecks
+}
+
+// getEntityValidateChecks checks all enum fields, the type fields of polymorphic For* relations and non-optional ForOne relations of an entity.
+func getEntityValidateChecks(config cfg.MorpheConfig, r *registry.Registry, entity yaml.Entity) ([]validateCheck, error) {
+	allEnums := r.GetAllEnums()
+	allChecks := []validateCheck{}
+	for _, fieldName := range core.MapKeysSorted(entity.Fields) {
+		entityField := entity.Fields[fieldName]
+		model, modelFieldName, modelField, modelFieldErr := getModelFieldByPath(r, entityField.Type)
+		if modelFieldErr != nil {
+			return nil, modelFieldErr
+		}
+		_, hasOverride := config.MorpheTypeOverridesConfig.GetModelFieldOverride(model.Name, modelFieldName, string(modelField.Type))
+		if _, isEnum := allEnums[string(modelField.Type)]; !isEnum || hasOverride {
+			continue
+		}
+		allChecks = append(allChecks, validateCheck{
+			Kind:      validateCheckEnum,
+			FieldName: fieldName,
+			Pointer:   hasAttribute(entityField.Attributes, "optional"),
+		})
+	}
+
+	for _, relationName := range core.MapKeysSorted(entity.Related) {
+		relationDef := yaml.ModelRelation(entity.Related[relationName])
+		if !isModelPolyForRelation(relationDef) {
+			allChecks = append(allChecks, getRelationValidateChecks(relationName, relationDef)...)
+			continue
+		}
+		allChecks = append(allChecks, validateCheck{
+			Kind:      validateCheckOneOf,
+			FieldName: relationName + "Type",
+			Pointer:   hasAttribute(relationDef.Attributes, "optional"),
+			OneOf:     getSortedPolyTargetNames(relationDef),
+		})
+	}
+	return allChecks, nil
+}
+
+// getStructureValidateChecks checks all enum fields and validates all nested structures of a structure.
+func getStructureValidateChecks(config cfg.MorpheConfig, r *registry.Registry, structure yaml.Structure) []validateCheck {
+	allEnums := r.GetAllEnums()
+	allStructures := r.GetAllStructures()
+	allChecks := []validateCheck{}
+	for _, fieldName := range core.MapKeysSorted(structure.Fields) {
+		fieldDef := structure.Fields[fieldName]
+		if _, hasOverride := config.MorpheTypeOverridesConfig.GetFieldTypeOverride(string(fieldDef.Type)); hasOverride {
+			continue
+		}
+		isOptional := hasAttribute(fieldDef.Attributes, "optional")
+		if enum, isEnum := allEnums[string(fieldDef.Type)]; isEnum {
+			// Structure enum fields are never pointers
+			enumCheck := validateCheck{
+				Kind:      validateCheckEnum,
+				FieldName: fieldName,
+			}
+			if isOptional {
+				enumCheck.Zero = getEnumZeroLiteral(enum)
+			}
+			allChecks = append(allChecks, enumCheck)
+			continue
+		}
+		if _, isStructure := allStructures[string(fieldDef.Type)]; isStructure {
+			allChecks = append(allChecks, validateCheck{
+				Kind:      validateCheckNested,
+				FieldName: fieldName,
+				Pointer:   isOptional,
+			})
+		}
+	}
+	return allChecks
+}
+
+func getEnumZeroLiteral(enum yaml.Enum) string {
+	if enum.Type == yaml.EnumTypeString {
+		return `""`
+	}
+	return "0"
+}
+
+// getRelationValidateChecks checks that non-optional (non polymorphic) ForOne relations are set.
+func getRelationValidateChecks(relationName string, relationDef yaml.ModelRelation) []validateCheck {
+	if yamlops.IsRelationFor(relationDef.Type) && yamlops.IsRelationOne(relationDef.Type) && !hasAttribute(relationDef.Attributes, "optional") {
+		return []validateCheck{
+			{
+				Kind:      validateCheckRequired,
+				FieldName: relationName,
+			},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/morphe-go/pkg/yamlops"
+)
+
+// validateNotRequiredFieldTypes are the field types whose zero value is a valid value, or which are assigned by the database
+var validateNotRequiredFieldTypes = []string{
+	string(yaml.ModelFieldTypeAutoIncrement),
+	string(yaml.ModelFieldTypeBoolean),
+	string(yaml.ModelFieldTypeFloat),
+	string(yaml.ModelFieldTypeInteger),
+}
+
+// buildValidateFieldTags constructs the go-playground/validator struct tag for a field. Optional fields are only validated when set.
+func buildValidateFieldTags(rules []string, optional bool) []string {
+	if len(rules) == 0 {
+		return nil
+	}
+	if optional {
+		rules = append([]string{"omitempty"}, rules...)
+	}
+	return []string{fmt.Sprintf("validate:\"%s\"", strings.Join(rules, ","))}
+}
+
+// getValidateFieldRules returns the validation rules of a direct field by its Morphe field type, which is either a primitive type or an enum name.
+// Overridden field types are only checked for presence, since their Go type is unknown.
+func getValidateFieldRules(fieldType string, optional bool, hasOverride bool, allEnums map[string]yaml.Enum) []string {
+	enum, isEnum := allEnums[fieldType]
+	if isEnum && !hasOverride {
+		return getValidateEnumFieldRules(enum, optional)
+	}
+
+	var rules []string
+	if !optional && !slices.Contains(validateNotRequiredFieldTypes, fieldType) {
+		rules = append(rules, "required")
+	}
+	if fieldType == string(yaml.ModelFieldTypeUUID) && !hasOverride {
+		rules = append(rules, "uuid")
+	}
+	return rules
+}
+
+// getValidateEnumFieldRules restricts enum fields to their entries.
+func getValidateEnumFieldRules(enum yaml.Enum, optional bool) []string {
+	var rules []string
+	if !optional && enum.Type == yaml.EnumTypeString {
+		rules = append(rules, "required")
+	}
+
+	oneOfValues := []string{}
+	for _, entryName := range core.MapKeysSorted(enum.Entries) {
+		oneOfValues = append(oneOfValues, getValidateOneOfValue(fmt.Sprint(enum.Entries[entryName])))
+	}
+	return append(rules, "oneof="+strings.Join(oneOfValues, " "))
+}
+
+// getValidateOneOfValue quotes values with spaces and escapes the validator separators (",", "|") in oneof values.
+func getValidateOneOfValue(value string) string {
+	value = strings.ReplaceAll(value, ",", "0x2C")
+	value = strings.ReplaceAll(value, "|", "0x7C")
+	if strings.Contains(value, " ") {
+		return "'" + value + "'"
+	}
+	return value
+}
+
+// getValidateRelatedIDFieldRules requires the related ID of a For* relation to a single struct (the foreign key).
+func getValidateRelatedIDFieldRules(relationType string, targetPrimaryIDFieldType string, hasOverride bool, optional bool) []string {
+	if !yamlops.IsRelationFor(relationType) || yamlops.IsRelationMany(relationType) {
+		return nil
+	}
+
+	var rules []string
+	if !optional {
+		rules = append(rules, "required")
+	}
+	if targetPrimaryIDFieldType == string(yaml.ModelFieldTypeUUID) && !hasOverride {
+		rules = append(rules, "uuid")
+	}
+	return rules
+}
+
+// getValidatePolyTypeFieldRules restricts the type field of a polymorphic For* relation to the linked structs.
+func getValidatePolyTypeFieldRules(relationFor []string, optional bool) []string {
+	var rules []string
+	if !optional {
+		rules = append(rules, "required")
+	}
+	targetNames := slices.Clone(relationFor)
+	slices.Sort(targetNames)
+	return append(rules, "oneof="+strings.Join(targetNames, " "))
+}
+
+// getValidatePolyIDFieldRules requires the ID field of a polymorphic For* relation.
+func getValidatePolyIDFieldRules(optional bool) []string {
+	if optional {
+		return nil
+	}
+	return []string{"required"}
+}
+
+// getValidateRelatedFieldRules validates every struct of a *Many relation.
+func getValidateRelatedFieldRules(relationType string) []string {
+	if !yamlops.IsRelationMany(relationType) {
+		return nil
+	}
+	return []string{"dive"}
+}
@@ -0,0 +1,7 @@
+package write
+
+// EnumDocsSetter is implemented by enum writers that render doc comments on the enum types and their entries.
+// The docs are keyed by enum name, enums without docs are rendered without comments.
+type EnumDocsSetter interface {
+	SetEnumDocs(allEnumDocs map[string]EnumDoc)
+}
@@ -0,0 +1,9 @@
+package write
+
+import "github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+
+// EnumsConfigSetter is implemented by enum writers whose output depends on the enums config,
+// ie. to generate optional marshalling methods.
+type EnumsConfigSetter interface {
+	SetEnumsConfig(enumsConfig cfg.MorpheEnumsConfig)
+}
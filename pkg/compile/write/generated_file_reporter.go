@@ -0,0 +1,10 @@
+package write
+
+import "github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+
+// GeneratedFileReporter is implemented by writers that report the files they wrote (or checked), ie. to print a summary
+// of a compilation.
+type GeneratedFileReporter interface {
+	// GetGeneratedFiles returns the files written since the last call, sorted by path.
+	GetGeneratedFiles() []gofile.GeneratedFile
+}
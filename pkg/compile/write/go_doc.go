@@ -0,0 +1,13 @@
+package write
+
+// StructDoc holds the doc comment lines of a struct type and its fields (field name -> lines), without the comment markers.
+type StructDoc struct {
+	Lines      []string
+	FieldLines map[string][]string
+}
+
+// EnumDoc holds the doc comment lines of an enum type and its entries (entry name -> lines), without the comment markers.
+type EnumDoc struct {
+	Lines      []string
+	EntryLines map[string][]string
+}
@@ -0,0 +1,10 @@
+package write
+
+import "github.com/kalo-build/plugin-morphe-go-struct/pkg/gofile"
+
+// GoFileChecker is implemented by writers that support a check mode, in which rendered definitions
+// are compared with the existing files instead of being written.
+type GoFileChecker interface {
+	SetCheckOnly(checkOnly bool)
+	GetCheckResult() (gofile.CheckResult, error)
+}
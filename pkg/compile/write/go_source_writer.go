@@ -0,0 +1,7 @@
+package write
+
+// GoSourceWriter is implemented by struct writers that can also write pre-rendered definitions into their target directory,
+// ie. the shared declarations of polymorphic relations. The file contents are complete, unformatted Go source files.
+type GoSourceWriter interface {
+	WriteSource(definitionName string, fileContents string) ([]byte, error)
+}
@@ -0,0 +1,7 @@
+package write
+
+// GoSupportWriter writes the support definitions shared by the generated packages, ie. the civil Date type.
+// The file contents are complete, unformatted Go source files.
+type GoSupportWriter interface {
+	WriteSupport(definitionName string, fileContents string) ([]byte, error)
+}
@@ -0,0 +1,7 @@
+package write
+
+// StaleFileRemover is implemented by writers that keep track of the files they write and can remove
+// previously generated files that are no longer produced.
+type StaleFileRemover interface {
+	RemoveStaleFiles() ([]string, error)
+}
@@ -0,0 +1,7 @@
+package write
+
+// StructDocsSetter is implemented by struct writers that render doc comments on the struct types and their fields.
+// The docs are keyed by struct name, structs without docs are rendered without comments.
+type StructDocsSetter interface {
+	SetStructDocs(allStructDocs map[string]StructDoc)
+}
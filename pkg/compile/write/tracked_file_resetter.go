@@ -0,0 +1,7 @@
+package write
+
+// TrackedFileResetter is implemented by writers that keep track of the files they write, so that a new compilation
+// starts without the files of a previous one, ie. a run without stale file cleanup.
+type TrackedFileResetter interface {
+	ResetTrackedFiles()
+}
@@ -0,0 +1,16 @@
+package compile
+
+import (
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/write"
+)
+
+// WriteCloneHelpersDefinition writes the helpers used by the generated Clone methods next to the structs of a package
+// and returns their formatted contents.
+func WriteCloneHelpersDefinition(writer write.GoStructWriter, structPackage godef.Package) ([]byte, error) {
+	sourceWriter, isSourceWriter := writer.(write.GoSourceWriter)
+	if !isSourceWriter {
+		return nil, ErrWriterSourceUnsupported
+	}
+	return sourceWriter.WriteSource(cloneHelpersDefinitionName, getCloneHelpersFileContents(structPackage))
+}
@@ -0,0 +1,16 @@
+package compile
+
+import (
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/write"
+)
+
+// WriteFieldChangeDefinition writes the FieldChange type returned by the generated Diff methods next to the structs
+// of a package and returns its formatted contents.
+func WriteFieldChangeDefinition(writer write.GoStructWriter, structPackage godef.Package) ([]byte, error) {
+	sourceWriter, isSourceWriter := writer.(write.GoSourceWriter)
+	if !isSourceWriter {
+		return nil, ErrWriterSourceUnsupported
+	}
+	return sourceWriter.WriteSource(fieldChangeDefinitionName, getFieldChangeFileContents(structPackage))
+}
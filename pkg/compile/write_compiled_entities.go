@@ -25,6 +25,30 @@ func WriteAllEntityStructDefinitions(config MorpheCompileConfig, allEntityStruct
 	return allWrittenEntities, nil
 }
 
+// WriteAllEntityModelMappers writes the model mapper functions of all entities next to the entity structs
+// and returns their formatted contents (definition name -> contents).
+func WriteAllEntityModelMappers(config MorpheCompileConfig, allMapperDefs map[string]string) (map[string][]byte, error) {
+	allWrittenMapperDefs := map[string][]byte{}
+	if len(allMapperDefs) == 0 {
+		return allWrittenMapperDefs, nil
+	}
+
+	sourceWriter, isSourceWriter := config.EntityWriter.(write.GoSourceWriter)
+	if !isSourceWriter {
+		return nil, ErrWriterSourceUnsupported
+	}
+
+	sortedDefinitionNames := core.MapKeysSorted(allMapperDefs)
+	for _, definitionName := range sortedDefinitionNames {
+		mapperContents, writeErr := sourceWriter.WriteSource(definitionName, allMapperDefs[definitionName])
+		if writeErr != nil {
+			return nil, writeErr
+		}
+		allWrittenMapperDefs[definitionName] = mapperContents
+	}
+	return allWrittenMapperDefs, nil
+}
+
 func WriteEntityStructDefinition(hooks hook.WriteGoStruct, writer write.GoStructWriter, entityStruct *godef.Struct) (*godef.Struct, []byte, error) {
 	writer, entityStruct, writeStartErr := triggerWriteEntityStructStart(hooks, writer, entityStruct)
 	if writeStartErr != nil {
@@ -26,6 +26,11 @@ func (c *CompiledEnums) AddCompiledEnum(enum *godef.Enum, contents []byte) {
 func WriteAllEnumDefinitions(config MorpheCompileConfig, allEnumDefs map[string]*godef.Enum) (CompiledEnums, error) {
 	allWrittenEnums := CompiledEnums{}
 
+	configSetter, isConfigSetter := config.EnumWriter.(write.EnumsConfigSetter)
+	if isConfigSetter {
+		configSetter.SetEnumsConfig(config.MorpheEnumsConfig)
+	}
+
 	sortedEnumNames := core.MapKeysSorted(allEnumDefs)
 	for _, enumName := range sortedEnumNames {
 		enumDef := allEnumDefs[enumName]
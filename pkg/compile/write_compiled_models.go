@@ -25,6 +25,54 @@ func WriteAllModelStructDefinitions(config MorpheCompileConfig, allModelStructDe
 	return allWrittenModels, nil
 }
 
+// WriteAllModelPolyDefinitions writes the shared declarations of all polymorphic relations next to the model structs
+// and returns their formatted contents (definition name -> contents).
+func WriteAllModelPolyDefinitions(config MorpheCompileConfig, allPolyDefs map[string]string) (map[string][]byte, error) {
+	allWrittenPolyDefs := map[string][]byte{}
+	if len(allPolyDefs) == 0 {
+		return allWrittenPolyDefs, nil
+	}
+
+	sourceWriter, isSourceWriter := config.ModelWriter.(write.GoSourceWriter)
+	if !isSourceWriter {
+		return nil, ErrWriterSourceUnsupported
+	}
+
+	sortedDefinitionNames := core.MapKeysSorted(allPolyDefs)
+	for _, definitionName := range sortedDefinitionNames {
+		polyContents, writeErr := sourceWriter.WriteSource(definitionName, allPolyDefs[definitionName])
+		if writeErr != nil {
+			return nil, writeErr
+		}
+		allWrittenPolyDefs[definitionName] = polyContents
+	}
+	return allWrittenPolyDefs, nil
+}
+
+// WriteAllModelConstructors writes the constructors of all models next to the model structs
+// and returns their formatted contents (definition name -> contents).
+func WriteAllModelConstructors(config MorpheCompileConfig, allConstructorDefs map[string]string) (map[string][]byte, error) {
+	allWrittenConstructorDefs := map[string][]byte{}
+	if len(allConstructorDefs) == 0 {
+		return allWrittenConstructorDefs, nil
+	}
+
+	sourceWriter, isSourceWriter := config.ModelWriter.(write.GoSourceWriter)
+	if !isSourceWriter {
+		return nil, ErrWriterSourceUnsupported
+	}
+
+	sortedDefinitionNames := core.MapKeysSorted(allConstructorDefs)
+	for _, definitionName := range sortedDefinitionNames {
+		constructorContents, writeErr := sourceWriter.WriteSource(definitionName, allConstructorDefs[definitionName])
+		if writeErr != nil {
+			return nil, writeErr
+		}
+		allWrittenConstructorDefs[definitionName] = constructorContents
+	}
+	return allWrittenConstructorDefs, nil
+}
+
 func WriteModelStructDefinition(hooks hook.WriteGoStruct, writer write.GoStructWriter, modelStruct *godef.Struct) (*godef.Struct, []byte, error) {
 	writer, modelStruct, writeStartErr := triggerWriteModelStructStart(hooks, writer, modelStruct)
 	if writeStartErr != nil {
@@ -0,0 +1,24 @@
+package compile
+
+import (
+	"github.com/kalo-build/go-util/core"
+)
+
+// WriteAllSupportDefinitions writes all support definitions enabled in the config and returns their formatted contents (definition name -> contents).
+func WriteAllSupportDefinitions(config MorpheCompileConfig) (map[string][]byte, error) {
+	allWrittenSupport := map[string][]byte{}
+	if config.SupportWriter == nil {
+		return allWrittenSupport, nil
+	}
+
+	allSupportDefs := AllSupportDefinitions(config.MorpheSupportConfig)
+	sortedDefinitionNames := core.MapKeysSorted(allSupportDefs)
+	for _, definitionName := range sortedDefinitionNames {
+		supportContents, writeErr := config.SupportWriter.WriteSupport(definitionName, allSupportDefs[definitionName])
+		if writeErr != nil {
+			return nil, writeErr
+		}
+		allWrittenSupport[definitionName] = supportContents
+	}
+	return allWrittenSupport, nil
+}
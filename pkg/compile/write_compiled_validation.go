@@ -0,0 +1,16 @@
+package compile
+
+import (
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/write"
+)
+
+// WriteValidationErrorsDefinition writes the ValidationErrors type returned by the generated Validate methods next to the structs
+// of a package and returns its formatted contents.
+func WriteValidationErrorsDefinition(writer write.GoStructWriter, structPackage godef.Package) ([]byte, error) {
+	sourceWriter, isSourceWriter := writer.(write.GoSourceWriter)
+	if !isSourceWriter {
+		return nil, ErrWriterSourceUnsupported
+	}
+	return sourceWriter.WriteSource(validationErrorsDefinitionName, getValidationErrorsFileContents(structPackage))
+}
@@ -0,0 +1,105 @@
+package gofile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// CheckResult describes how generated output differs from the files currently on disk.
+type CheckResult struct {
+	// Changed contains a unified diff for every generated file whose contents differ from the existing file
+	Changed []FileDiff
+
+	// Missing contains the paths of generated files that do not exist yet
+	Missing []string
+
+	// Extra contains the paths of existing generated files that are no longer produced
+	Extra []string
+}
+
+// FileDiff is the unified diff between an existing file and its regenerated contents.
+type FileDiff struct {
+	FilePath string
+	Diff     string
+}
+
+// IsClean returns true if the generated output matches the files on disk.
+func (r CheckResult) IsClean() bool {
+	return len(r.Changed) == 0 && len(r.Missing) == 0 && len(r.Extra) == 0
+}
+
+// Merge appends all entries of the other result and keeps all entries sorted by file path.
+func (r *CheckResult) Merge(other CheckResult) {
+	r.Changed = append(r.Changed, other.Changed...)
+	r.Missing = append(r.Missing, other.Missing...)
+	r.Extra = append(r.Extra, other.Extra...)
+
+	sort.Slice(r.Changed, func(i, j int) bool {
+		return r.Changed[i].FilePath < r.Changed[j].FilePath
+	})
+	sort.Strings(r.Missing)
+	sort.Strings(r.Extra)
+}
+
+// String renders the result as unified diffs followed by the lists of missing and extra files.
+func (r CheckResult) String() string {
+	var builder strings.Builder
+	for _, fileDiff := range r.Changed {
+		builder.WriteString(fileDiff.Diff)
+	}
+	for _, missingFilePath := range r.Missing {
+		builder.WriteString(fmt.Sprintf("missing: %s\n", missingFilePath))
+	}
+	for _, extraFilePath := range r.Extra {
+		builder.WriteString(fmt.Sprintf("extra: %s\n", extraFilePath))
+	}
+	return builder.String()
+}
+
+// CheckGoDefinitionFile compares the formatted contents of a definition with the existing definition file in dirPath without writing anything.
+func CheckGoDefinitionFile(outputFS OutputFS, dirPath string, definitionName string, formattedContents []byte) (CheckResult, error) {
+	definitionFilePath := filepath.Join(dirPath, GetDefinitionFileName(definitionName))
+
+	existingContents, readErr := outputFS.ReadFile(definitionFilePath)
+	if readErr != nil {
+		if errors.Is(readErr, fs.ErrNotExist) {
+			return CheckResult{Missing: []string{definitionFilePath}}, nil
+		}
+		return CheckResult{}, readErr
+	}
+
+	if bytes.Equal(existingContents, formattedContents) {
+		return CheckResult{}, nil
+	}
+
+	fileDiff, diffErr := GetUnifiedDiff(definitionFilePath, existingContents, formattedContents)
+	if diffErr != nil {
+		return CheckResult{}, diffErr
+	}
+	return CheckResult{Changed: []FileDiff{fileDiff}}, nil
+}
+
+// GetUnifiedDiff returns the unified diff from the existing to the generated contents of the file at filePath.
+func GetUnifiedDiff(filePath string, existingContents []byte, generatedContents []byte) (FileDiff, error) {
+	diff, diffErr := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(existingContents)),
+		B:        difflib.SplitLines(string(generatedContents)),
+		FromFile: filePath,
+		ToFile:   filePath + " (generated)",
+		Context:  3,
+	})
+	if diffErr != nil {
+		return FileDiff{}, diffErr
+	}
+	return FileDiff{
+		FilePath: filePath,
+		Diff:     diff,
+	}, nil
+}
@@ -1,26 +1,100 @@
 package gofile
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"go/format"
-	"os"
+	"io/fs"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/kalo-build/go-util/strcase"
 )
 
-func WriteGoDefinitionFile(dirPath string, definitionName string, goFileContents string) ([]byte, error) {
-	formattedStructContents, formatErr := format.Source([]byte(goFileContents))
+// GeneratedFileHeader is the first line of every file written by this plugin.
+// It follows the standard Go convention for generated code (see `go help generate`),
+// which is recognized by `go vet`, linters and most editors.
+const GeneratedFileHeader = "// Code generated by plugin-morphe-go-struct. DO NOT EDIT."
+
+// WriteGoDefinitionFile formats the Go file contents and writes them to the definition file in dirPath.
+func WriteGoDefinitionFile(outputFS OutputFS, dirPath string, definitionName string, goFileContents string) ([]byte, error) {
+	formattedStructContents, formatErr := FormatGoDefinitionFile(goFileContents)
 	if formatErr != nil {
 		return nil, formatErr
 	}
 
-	definitionFileName := strcase.ToSnakeCaseLower(definitionName)
-	definitionFilePath := filepath.Join(dirPath, definitionFileName+".go")
-	if _, readErr := os.ReadDir(dirPath); readErr != nil && os.IsNotExist(readErr) {
-		mkDirErr := os.MkdirAll(dirPath, 0644)
-		if mkDirErr != nil {
-			return nil, mkDirErr
+	definitionFilePath := filepath.Join(dirPath, GetDefinitionFileName(definitionName))
+	return formattedStructContents, outputFS.WriteFile(definitionFilePath, formattedStructContents)
+}
+
+// FormatGoDefinitionFile formats the Go file contents the same way they are written to disk.
+func FormatGoDefinitionFile(goFileContents string) ([]byte, error) {
+	return format.Source([]byte(goFileContents))
+}
+
+// GetDefinitionFileName returns the file name used for the given definition, ie. "person_id_name.go" for "PersonIDName".
+func GetDefinitionFileName(definitionName string) string {
+	return strcase.ToSnakeCaseLower(definitionName) + ".go"
+}
+
+// IsGeneratedGoFile returns true if the file contents start with the GeneratedFileHeader.
+func IsGeneratedGoFile(goFileContents []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(goFileContents))
+	if !scanner.Scan() {
+		return false
+	}
+	return strings.TrimRight(scanner.Text(), "\r") == GeneratedFileHeader
+}
+
+// RemoveStaleGoDefinitionFiles deletes all generated Go files in dirPath whose file names are not in keepFileNames.
+// Files without the GeneratedFileHeader are never removed. It returns the paths of all removed files.
+func RemoveStaleGoDefinitionFiles(outputFS OutputFS, dirPath string, keepFileNames map[string]any) ([]string, error) {
+	staleFilePaths, staleErr := FindStaleGoDefinitionFiles(outputFS, dirPath, keepFileNames)
+	if staleErr != nil {
+		return nil, staleErr
+	}
+
+	for _, staleFilePath := range staleFilePaths {
+		removeErr := outputFS.Remove(staleFilePath)
+		if removeErr != nil {
+			return nil, removeErr
 		}
 	}
-	return formattedStructContents, os.WriteFile(definitionFilePath, formattedStructContents, 0644)
+	return staleFilePaths, nil
+}
+
+// FindStaleGoDefinitionFiles returns the sorted paths of all generated Go files in dirPath whose file names are not in keepFileNames.
+func FindStaleGoDefinitionFiles(outputFS OutputFS, dirPath string, keepFileNames map[string]any) ([]string, error) {
+	allFileNames, readDirErr := outputFS.ReadDir(dirPath)
+	if readDirErr != nil {
+		if errors.Is(readDirErr, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, readDirErr
+	}
+
+	staleFilePaths := []string{}
+	for _, fileName := range allFileNames {
+		if filepath.Ext(fileName) != ".go" {
+			continue
+		}
+		if _, keep := keepFileNames[fileName]; keep {
+			continue
+		}
+
+		filePath := filepath.Join(dirPath, fileName)
+		fileContents, readErr := outputFS.ReadFile(filePath)
+		if readErr != nil {
+			return nil, readErr
+		}
+		if !IsGeneratedGoFile(fileContents) {
+			continue
+		}
+		staleFilePaths = append(staleFilePaths, filePath)
+	}
+	sort.Strings(staleFilePaths)
+
+	return staleFilePaths, nil
 }
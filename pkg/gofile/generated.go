@@ -0,0 +1,35 @@
+package gofile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"sort"
+)
+
+// GeneratedFile is a definition file written (or checked) by a writer.
+type GeneratedFile struct {
+	// Path is the path of the file in the output filesystem, ie. "models/person.go"
+	Path string `json:"path"`
+	// Definition is the name of the written definition, ie. "Person"
+	Definition string `json:"definition"`
+	// Hash is the SHA-256 of the formatted file contents, ie. "sha256:9f86d0..."
+	Hash string `json:"hash"`
+}
+
+// NewGeneratedFile returns the GeneratedFile of a definition written to dirPath.
+func NewGeneratedFile(dirPath string, definitionName string, formattedContents []byte) GeneratedFile {
+	contentsHash := sha256.Sum256(formattedContents)
+	return GeneratedFile{
+		Path:       filepath.Join(dirPath, GetDefinitionFileName(definitionName)),
+		Definition: definitionName,
+		Hash:       "sha256:" + hex.EncodeToString(contentsHash[:]),
+	}
+}
+
+// SortGeneratedFiles sorts the files by path.
+func SortGeneratedFiles(allFiles []GeneratedFile) {
+	sort.Slice(allFiles, func(i, j int) bool {
+		return allFiles[i].Path < allFiles[j].Path
+	})
+}
@@ -0,0 +1,174 @@
+package gofile
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// OutputFS is the filesystem generated definition files are written to.
+//
+// Paths are slash-separated for in-memory implementations and native for OSFS.
+// Missing files and directories are reported with errors matching fs.ErrNotExist.
+type OutputFS interface {
+	ReadFile(filePath string) ([]byte, error)
+
+	// WriteFile writes the file contents, creating all missing parent directories
+	WriteFile(filePath string, contents []byte) error
+
+	// ReadDir returns the sorted names of all files (not directories) directly inside the directory
+	ReadDir(dirPath string) ([]string, error)
+
+	Remove(filePath string) error
+}
+
+// OSFS writes to the local filesystem. All paths are relative to RootDirPath, or used as-is if RootDirPath is empty.
+type OSFS struct {
+	RootDirPath string
+}
+
+func (o OSFS) ReadFile(filePath string) ([]byte, error) {
+	return os.ReadFile(o.getPath(filePath))
+}
+
+func (o OSFS) WriteFile(filePath string, contents []byte) error {
+	fullPath := o.getPath(filePath)
+	mkDirErr := os.MkdirAll(filepath.Dir(fullPath), 0755)
+	if mkDirErr != nil {
+		return mkDirErr
+	}
+	return os.WriteFile(fullPath, contents, 0644)
+}
+
+func (o OSFS) ReadDir(dirPath string) ([]string, error) {
+	dirEntries, readDirErr := os.ReadDir(o.getPath(dirPath))
+	if readDirErr != nil {
+		return nil, readDirErr
+	}
+
+	fileNames := []string{}
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() {
+			continue
+		}
+		fileNames = append(fileNames, dirEntry.Name())
+	}
+	return fileNames, nil
+}
+
+func (o OSFS) Remove(filePath string) error {
+	return os.Remove(o.getPath(filePath))
+}
+
+func (o OSFS) getPath(filePath string) string {
+	if o.RootDirPath == "" {
+		return filePath
+	}
+	return filepath.Join(o.RootDirPath, filePath)
+}
+
+// MemFS keeps all files in memory, ie. to collect generated output in tests or when embedding the compiler in another generator.
+// The zero value is an empty filesystem ready to use.
+type MemFS struct {
+	mutex sync.RWMutex
+	files map[string][]byte
+}
+
+// NewMemFS returns an in-memory filesystem pre-populated with the given files (path -> contents).
+func NewMemFS(files map[string][]byte) *MemFS {
+	memFS := &MemFS{}
+	for filePath, contents := range files {
+		memFS.WriteFile(filePath, contents)
+	}
+	return memFS
+}
+
+func (m *MemFS) ReadFile(filePath string) ([]byte, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	contents, fileExists := m.files[cleanMemPath(filePath)]
+	if !fileExists {
+		return nil, &fs.PathError{Op: "read", Path: filePath, Err: fs.ErrNotExist}
+	}
+	return cloneBytes(contents), nil
+}
+
+func (m *MemFS) WriteFile(filePath string, contents []byte) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.files == nil {
+		m.files = map[string][]byte{}
+	}
+	m.files[cleanMemPath(filePath)] = cloneBytes(contents)
+	return nil
+}
+
+func (m *MemFS) ReadDir(dirPath string) ([]string, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	cleanDirPath := cleanMemPath(dirPath)
+	dirExists := false
+	fileNames := []string{}
+	for filePath := range m.files {
+		if !isMemPathInDir(filePath, cleanDirPath) {
+			continue
+		}
+		dirExists = true
+		if path.Dir(filePath) == cleanDirPath {
+			fileNames = append(fileNames, path.Base(filePath))
+		}
+	}
+	if !dirExists {
+		return nil, &fs.PathError{Op: "readdir", Path: dirPath, Err: fs.ErrNotExist}
+	}
+	sort.Strings(fileNames)
+	return fileNames, nil
+}
+
+func (m *MemFS) Remove(filePath string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	cleanFilePath := cleanMemPath(filePath)
+	if _, fileExists := m.files[cleanFilePath]; !fileExists {
+		return &fs.PathError{Op: "remove", Path: filePath, Err: fs.ErrNotExist}
+	}
+	delete(m.files, cleanFilePath)
+	return nil
+}
+
+// Files returns a copy of all files (path -> contents).
+func (m *MemFS) Files() map[string][]byte {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	files := make(map[string][]byte, len(m.files))
+	for filePath, contents := range m.files {
+		files[filePath] = cloneBytes(contents)
+	}
+	return files
+}
+
+func cleanMemPath(filePath string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(filePath)), "/")
+}
+
+func isMemPathInDir(filePath string, dirPath string) bool {
+	if dirPath == "." {
+		return true
+	}
+	return strings.HasPrefix(filePath, dirPath+"/")
+}
+
+func cloneBytes(contents []byte) []byte {
+	contentsClone := make([]byte, len(contents))
+	copy(contentsClone, contents)
+	return contentsClone
+}
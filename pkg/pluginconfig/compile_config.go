@@ -0,0 +1,144 @@
+package pluginconfig
+
+// CompileConfig is the config of a plugin run. The options of the "config" key are declared with struct tags, which
+// generate the configSchema of plugin.yaml (see GetConfigSchema):
+//   - json: the config key
+//   - description: the description of the option
+//   - enum: the comma separated valid values, a trailing comma allows the empty string
+//   - default: the value used if the option is not set
+//   - required: "true" if the option must be set
+type CompileConfig struct {
+	InputPath  string               `json:"inputPath"`
+	OutputPath string               `json:"outputPath"`
+	Config     CompileConfigEntries `json:"config"`
+	Verbose    bool                 `json:"verbose,omitempty"`
+
+	// Check compares the generated output with the existing files in the output path without writing anything.
+	Check bool `json:"check,omitempty"`
+
+	// FailFast stops at the first failing definition instead of reporting every failure.
+	FailFast bool `json:"failFast,omitempty"`
+
+	// Diagnostics is the output format of the run: "text" (default) or "json", overridden by the --diagnostics flag.
+	Diagnostics string `json:"diagnostics,omitempty"`
+}
+
+type CompileConfigEntries struct {
+	// FieldCasing applies to all sections (models, structures, entities) that don't set their own fieldCasing.
+	// Valid values: "camel", "snake", "pascal", or "" (none / no JSON tags).
+	FieldCasing string `json:"fieldCasing,omitempty" description:"Field casing for JSON struct tags. Applies to models, structures, and entities unless they set their own fieldCasing. Valid values: camel, snake, pascal, or empty (no JSON tags)." enum:"camel,snake,pascal," default:""`
+
+	Models     CompileConfigModels     `json:"models" description:"Model generation configuration"`
+	Enums      CompileConfigEnums      `json:"enums" description:"Enum generation configuration"`
+	Structures CompileConfigStructures `json:"structures" description:"Structure generation configuration"`
+	Entities   CompileConfigEntities   `json:"entities" description:"Entity generation configuration"`
+	Support    CompileConfigSupport    `json:"support,omitempty" description:"Support package configuration, required if a support type (civil dates, redacted secrets) is generated"`
+
+	TypeOverrides CompileConfigTypeOverrides `json:"typeOverrides,omitempty" description:"Go types replacing the generated field types, ie. {\"packagePath\": \"github.com/google/uuid\", \"name\": \"UUID\"}. Also applies to related ID fields and identifier structs."`
+
+	RemoveStaleFiles bool `json:"removeStaleFiles,omitempty" description:"Delete previously generated files that are no longer produced by the registry from the output directories" default:"false"`
+}
+
+type CompileConfigModels struct {
+	PackagePath  string `json:"packagePath" description:"Go package path for generated model files" required:"true"`
+	PackageName  string `json:"packageName,omitempty" description:"Go package name of the generated model files" default:"models"`
+	ReceiverName string `json:"receiverName,omitempty" description:"Receiver name of the generated model methods" default:"m"`
+	FieldCasing  string `json:"fieldCasing,omitempty" description:"Field casing for the JSON struct tags of models, overrides config.fieldCasing" enum:"camel,snake,pascal,"`
+	DocComments  bool   `json:"docComments,omitempty" description:"Render doc comments from the YAML descriptions and the schema"`
+	FieldOrder   string `json:"fieldOrder,omitempty" description:"Order of the struct fields: declaration (YAML source order), or empty (sorted by name)" enum:"declaration,"`
+
+	JSONTags CompileConfigJSONTags `json:"jsonTags,omitempty" description:"JSON struct tag options of models"`
+	ORMTags  CompileConfigORMTags  `json:"ormTags,omitempty" description:"ORM struct tags of models"`
+
+	ValidateTags       bool `json:"validateTags,omitempty" description:"Add go-playground/validator struct tags"`
+	ValidateMethods    bool `json:"validateMethods,omitempty" description:"Generate a dependency-free Validate() error method per model"`
+	TypedIDs           bool `json:"typedIDs,omitempty" description:"Generate a named ID type per model, used by all fields referencing the model"`
+	TypedPolyRelations bool `json:"typedPolyRelations,omitempty" description:"Generate type enums, marker interfaces and typed helpers for polymorphic relations"`
+	Constructors       bool `json:"constructors,omitempty" description:"Generate New<Model> constructors and chainable With<Field> methods"`
+	CloneMethods       bool `json:"cloneMethods,omitempty" description:"Generate a deep copy Clone() method per model"`
+	DiffMethods        bool `json:"diffMethods,omitempty" description:"Generate Equal and Diff methods per model"`
+}
+
+type CompileConfigEnums struct {
+	PackagePath     string `json:"packagePath" description:"Go package path for generated enum files" required:"true"`
+	PackageName     string `json:"packageName,omitempty" description:"Go package name of the generated enum files" default:"enums"`
+	TextMarshalling bool   `json:"textMarshalling,omitempty" description:"Generate MarshalText and UnmarshalText methods, encoding the underlying value like JSON and SQL"`
+	JSONMarshalling bool   `json:"jsonMarshalling,omitempty" description:"Generate MarshalJSON and UnmarshalJSON methods"`
+	SQLMarshalling  bool   `json:"sqlMarshalling,omitempty" description:"Generate Value and Scan methods"`
+	DocComments     bool   `json:"docComments,omitempty" description:"Render doc comments from the YAML descriptions and the schema"`
+}
+
+type CompileConfigStructures struct {
+	PackagePath  string `json:"packagePath" description:"Go package path for generated structure files" required:"true"`
+	PackageName  string `json:"packageName,omitempty" description:"Go package name of the generated structure files" default:"structures"`
+	ReceiverName string `json:"receiverName,omitempty" description:"Receiver name of the generated structure methods" default:"s"`
+	FieldCasing  string `json:"fieldCasing,omitempty" description:"Field casing for the JSON struct tags of structures, overrides config.fieldCasing" enum:"camel,snake,pascal,"`
+	DocComments  bool   `json:"docComments,omitempty" description:"Render doc comments from the YAML descriptions and the schema"`
+	FieldOrder   string `json:"fieldOrder,omitempty" description:"Order of the struct fields: declaration (YAML source order), or empty (sorted by name)" enum:"declaration,"`
+
+	JSONTags CompileConfigJSONTags `json:"jsonTags,omitempty" description:"JSON struct tag options of structures"`
+
+	ValidateTags    bool `json:"validateTags,omitempty" description:"Add go-playground/validator struct tags"`
+	ValidateMethods bool `json:"validateMethods,omitempty" description:"Generate a dependency-free Validate() error method per structure"`
+	CloneMethods    bool `json:"cloneMethods,omitempty" description:"Generate a deep copy Clone() method per structure"`
+}
+
+type CompileConfigEntities struct {
+	PackagePath  string `json:"packagePath" description:"Go package path for generated entity files" required:"true"`
+	PackageName  string `json:"packageName,omitempty" description:"Go package name of the generated entity files" default:"entities"`
+	ReceiverName string `json:"receiverName,omitempty" description:"Receiver name of the generated entity methods" default:"e"`
+	FieldCasing  string `json:"fieldCasing,omitempty" description:"Field casing for the JSON struct tags of entities, overrides config.fieldCasing" enum:"camel,snake,pascal,"`
+	DocComments  bool   `json:"docComments,omitempty" description:"Render doc comments from the YAML descriptions and the schema"`
+	FieldOrder   string `json:"fieldOrder,omitempty" description:"Order of the struct fields: declaration (YAML source order), or empty (sorted by name)" enum:"declaration,"`
+
+	JSONTags CompileConfigJSONTags `json:"jsonTags,omitempty" description:"JSON struct tag options of entities"`
+	ORMTags  CompileConfigORMTags  `json:"ormTags,omitempty" description:"ORM struct tags of entities"`
+
+	ValidateTags    bool `json:"validateTags,omitempty" description:"Add go-playground/validator struct tags"`
+	ValidateMethods bool `json:"validateMethods,omitempty" description:"Generate a dependency-free Validate() error method per entity"`
+	ModelMappers    bool `json:"modelMappers,omitempty" description:"Generate a <Entity>FromModel function per entity"`
+	CloneMethods    bool `json:"cloneMethods,omitempty" description:"Generate a deep copy Clone() method per entity"`
+	DiffMethods     bool `json:"diffMethods,omitempty" description:"Generate Equal and Diff methods per entity"`
+}
+
+type CompileConfigSupport struct {
+	PackagePath   string                    `json:"packagePath,omitempty" description:"Go package path for generated support files"`
+	PackageName   string                    `json:"packageName,omitempty" description:"Go package name of the generated support files" default:"support"`
+	CivilDate     bool                      `json:"civilDate,omitempty" description:"Map Date fields to a civil date type serialized as YYYY-MM-DD instead of time.Time"`
+	DateType      CompileConfigTypeOverride `json:"dateType,omitempty" description:"Existing civil date type used instead of a generated one, ie. {\"packagePath\": \"cloud.google.com/go/civil\", \"name\": \"Date\"}"`
+	RedactSecrets bool                      `json:"redactSecrets,omitempty" description:"Map Protected and Sealed fields to wrapper types that redact their value"`
+}
+
+type CompileConfigJSONTags struct {
+	OmitOptional string `json:"omitOptional,omitempty" description:"Option added to the JSON tags of pointer fields" enum:"omitempty,omitzero,"`
+	Relations    string `json:"relations,omitempty" description:"Marshalling of related structs: omitempty, exclude, or empty (include)" enum:"omitempty,exclude,"`
+
+	// Fields overrides single fields, keyed by "Struct.Field"
+	Fields map[string]CompileConfigJSONFieldOverride `json:"fields,omitempty" description:"JSON tag overrides of single fields, keyed by Struct.Field (ie. Person.ID), ie. {\"name\": \"id\", \"string\": true}"`
+}
+
+type CompileConfigJSONFieldOverride struct {
+	Name   string `json:"name,omitempty" description:"JSON key of the field"`
+	String bool   `json:"string,omitempty" description:"Add the string option, encoding numbers and booleans as JSON strings"`
+}
+
+type CompileConfigORMTags struct {
+	DB           bool   `json:"db,omitempty" description:"Add db struct tags"`
+	Gorm         bool   `json:"gorm,omitempty" description:"Add gorm struct tags"`
+	Bun          bool   `json:"bun,omitempty" description:"Add bun struct tags"`
+	ColumnCasing string `json:"columnCasing,omitempty" description:"Casing of the column names in all ORM tags, snake if empty" enum:"camel,snake,pascal,"`
+}
+
+type CompileConfigTypeOverride struct {
+	// PackagePath is the import path of the type, empty for predeclared types
+	PackagePath string `json:"packagePath,omitempty" description:"Import path of the type, empty for predeclared types"`
+	Name        string `json:"name" description:"Name of the type"`
+}
+
+type CompileConfigTypeOverrides struct {
+	// FieldTypes overrides all fields of a Morphe type, ie. "UUID"
+	FieldTypes map[string]CompileConfigTypeOverride `json:"fieldTypes,omitempty" description:"Overrides for all fields of a Morphe type, keyed by type name (ie. UUID)"`
+
+	// ModelFields overrides single model fields, ie. "Invoice.Amount"
+	ModelFields map[string]CompileConfigTypeOverride `json:"modelFields,omitempty" description:"Overrides for single model fields, keyed by Model.Field (ie. Invoice.Amount). Take precedence over fieldTypes."`
+}
@@ -0,0 +1,121 @@
+package pluginconfig
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SchemaProperty is a property of the configSchema declared in plugin.yaml.
+type SchemaProperty struct {
+	Type        string                    `yaml:"type"`
+	Description string                    `yaml:"description,omitempty"`
+	Enum        []any                     `yaml:"enum,omitempty"`
+	Default     any                       `yaml:"default,omitempty"`
+	Required    bool                      `yaml:"required,omitempty"`
+	Properties  map[string]SchemaProperty `yaml:"properties,omitempty"`
+
+	// AdditionalProperties is the property of every entry of a map, ie. the type overrides by field type
+	AdditionalProperties *SchemaProperty `yaml:"additionalProperties,omitempty"`
+}
+
+// GetConfigSchema generates the configSchema of plugin.yaml from the struct tags of CompileConfigEntries.
+func GetConfigSchema() map[string]SchemaProperty {
+	return getSchemaProperties(reflect.TypeOf(CompileConfigEntries{}))
+}
+
+// GetConfigKeys returns the top-level keys of the config, ie. "inputPath" and "config".
+func GetConfigKeys() []string {
+	allKeys := []string{}
+	configType := reflect.TypeOf(CompileConfig{})
+	for fieldIdx := 0; fieldIdx < configType.NumField(); fieldIdx++ {
+		if key := getJSONKey(configType.Field(fieldIdx)); key != "" {
+			allKeys = append(allKeys, key)
+		}
+	}
+	return allKeys
+}
+
+func getSchemaProperties(structType reflect.Type) map[string]SchemaProperty {
+	allProperties := map[string]SchemaProperty{}
+	for fieldIdx := 0; fieldIdx < structType.NumField(); fieldIdx++ {
+		field := structType.Field(fieldIdx)
+		key := getJSONKey(field)
+		if key == "" {
+			continue
+		}
+		allProperties[key] = getSchemaProperty(field)
+	}
+	return allProperties
+}
+
+func getSchemaProperty(field reflect.StructField) SchemaProperty {
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	property := getSchemaTypeProperty(fieldType)
+	property.Description = field.Tag.Get("description")
+	property.Required = field.Tag.Get("required") == "true"
+	if enumTag, hasEnum := field.Tag.Lookup("enum"); hasEnum {
+		for _, enumValue := range strings.Split(enumTag, ",") {
+			property.Enum = append(property.Enum, enumValue)
+		}
+	}
+	if defaultTag, hasDefault := field.Tag.Lookup("default"); hasDefault {
+		property.Default = getSchemaDefault(fieldType, defaultTag)
+	}
+	return property
+}
+
+// getSchemaTypeProperty returns the property of a type without the options of its struct tags. Structs declare their
+// properties, maps hold arbitrary keys (ie. the type overrides by field type) whose values share one property.
+func getSchemaTypeProperty(fieldType reflect.Type) SchemaProperty {
+	property := SchemaProperty{
+		Type: getSchemaType(fieldType),
+	}
+	switch fieldType.Kind() {
+	case reflect.Struct:
+		property.Properties = getSchemaProperties(fieldType)
+	case reflect.Map:
+		valueProperty := getSchemaTypeProperty(fieldType.Elem())
+		property.AdditionalProperties = &valueProperty
+	}
+	return property
+}
+
+func getSchemaType(fieldType reflect.Type) string {
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int64:
+		return "integer"
+	case reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	}
+	return "string"
+}
+
+func getSchemaDefault(fieldType reflect.Type, defaultTag string) any {
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return defaultTag == "true"
+	case reflect.Int, reflect.Int64:
+		defaultValue, _ := strconv.Atoi(defaultTag)
+		return defaultValue
+	}
+	return defaultTag
+}
+
+// getJSONKey returns the JSON key of a struct field, or "" if the field is not decoded.
+func getJSONKey(field reflect.StructField) string {
+	key, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if key == "-" {
+		return ""
+	}
+	return key
+}
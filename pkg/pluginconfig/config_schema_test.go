@@ -0,0 +1,142 @@
+package pluginconfig_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kalo-build/go/pkg/godef"
+	plugin "github.com/kalo-build/plugin-morphe-go-struct"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/pluginconfig"
+	"github.com/stretchr/testify/suite"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+type ConfigSchemaTestSuite struct {
+	suite.Suite
+}
+
+func TestConfigSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigSchemaTestSuite))
+}
+
+func (suite *ConfigSchemaTestSuite) TestGetConfigSchema_MatchesManifest() {
+	var manifest struct {
+		ConfigSchema map[string]pluginconfig.SchemaProperty `yaml:"configSchema"`
+	}
+	unmarshalErr := yaml3.Unmarshal(plugin.Manifest, &manifest)
+	suite.Nil(unmarshalErr)
+
+	// Round trip the generated schema, so both sides hold the types decoded from YAML
+	schemaYAML, marshalErr := yaml3.Marshal(pluginconfig.GetConfigSchema())
+	suite.Nil(marshalErr)
+	var configSchema map[string]pluginconfig.SchemaProperty
+	suite.Nil(yaml3.Unmarshal(schemaYAML, &configSchema))
+
+	suite.Equal(configSchema, manifest.ConfigSchema, "plugin.yaml is out of date, run `go generate`")
+}
+
+func (suite *ConfigSchemaTestSuite) TestGetConfigSchema_Properties() {
+	configSchema := pluginconfig.GetConfigSchema()
+
+	modelsSchema := configSchema["models"]
+	suite.Equal("object", modelsSchema.Type)
+	suite.True(modelsSchema.Properties["packagePath"].Required)
+	suite.Equal("m", modelsSchema.Properties["receiverName"].Default)
+	suite.Equal("boolean", modelsSchema.Properties["typedIDs"].Type)
+	suite.Equal([]any{"camel", "snake", "pascal", ""}, modelsSchema.Properties["fieldCasing"].Enum)
+	suite.Equal("boolean", modelsSchema.Properties["ormTags"].Properties["gorm"].Type)
+
+	suite.Equal(false, configSchema["removeStaleFiles"].Default)
+	suite.Equal("", configSchema["fieldCasing"].Default)
+
+	// Keyed by field type, so no properties are declared
+	suite.Equal("object", configSchema["typeOverrides"].Properties["fieldTypes"].Type)
+	suite.Nil(configSchema["typeOverrides"].Properties["fieldTypes"].Properties)
+}
+
+// Every option of the compile config sections must be exposed by the plugin config, a godef.Package as PackagePath and PackageName
+func (suite *ConfigSchemaTestSuite) TestCompileConfig_ExposesAllOptions() {
+	allSectionTypes := map[reflect.Type]reflect.Type{
+		reflect.TypeOf(cfg.MorpheModelsConfig{}):     reflect.TypeOf(pluginconfig.CompileConfigModels{}),
+		reflect.TypeOf(cfg.MorpheEnumsConfig{}):      reflect.TypeOf(pluginconfig.CompileConfigEnums{}),
+		reflect.TypeOf(cfg.MorpheStructuresConfig{}): reflect.TypeOf(pluginconfig.CompileConfigStructures{}),
+		reflect.TypeOf(cfg.MorpheEntitiesConfig{}):   reflect.TypeOf(pluginconfig.CompileConfigEntities{}),
+		reflect.TypeOf(cfg.MorpheSupportConfig{}):    reflect.TypeOf(pluginconfig.CompileConfigSupport{}),
+		reflect.TypeOf(cfg.JSONTagsConfig{}):         reflect.TypeOf(pluginconfig.CompileConfigJSONTags{}),
+		reflect.TypeOf(cfg.JSONFieldOverride{}):      reflect.TypeOf(pluginconfig.CompileConfigJSONFieldOverride{}),
+		reflect.TypeOf(cfg.ORMTagsConfig{}):          reflect.TypeOf(pluginconfig.CompileConfigORMTags{}),
+		reflect.TypeOf(cfg.TypeOverride{}):           reflect.TypeOf(pluginconfig.CompileConfigTypeOverride{}),
+	}
+
+	for configType, pluginType := range allSectionTypes {
+		for fieldIdx := 0; fieldIdx < configType.NumField(); fieldIdx++ {
+			field := configType.Field(fieldIdx)
+			allFieldNames := []string{field.Name}
+			if field.Type == reflect.TypeOf(godef.Package{}) {
+				allFieldNames = []string{"PackagePath", "PackageName"}
+			}
+			for _, fieldName := range allFieldNames {
+				_, hasField := pluginType.FieldByName(fieldName)
+				suite.True(hasField, "%s.%s is not exposed by %s", configType.Name(), fieldName, pluginType.Name())
+			}
+		}
+	}
+}
+
+func (suite *ConfigSchemaTestSuite) TestApplyTo() {
+	entries := pluginconfig.CompileConfigEntries{
+		FieldCasing: "camel",
+		Models: pluginconfig.CompileConfigModels{
+			PackagePath: "github.com/kalo-build/project/domain/models",
+			PackageName: "domain",
+			TypedIDs:    true,
+			ORMTags: pluginconfig.CompileConfigORMTags{
+				Gorm: true,
+			},
+		},
+		Enums: pluginconfig.CompileConfigEnums{
+			PackagePath:     "github.com/kalo-build/project/domain/enums",
+			JSONMarshalling: true,
+		},
+		Structures: pluginconfig.CompileConfigStructures{
+			PackagePath: "github.com/kalo-build/project/domain/structures",
+			FieldCasing: "snake",
+		},
+		Entities: pluginconfig.CompileConfigEntities{
+			PackagePath:  "github.com/kalo-build/project/domain/entities",
+			ReceiverName: "ent",
+			ModelMappers: true,
+		},
+		Support: pluginconfig.CompileConfigSupport{
+			PackagePath: "github.com/kalo-build/project/domain/support",
+			CivilDate:   true,
+		},
+		RemoveStaleFiles: true,
+	}
+
+	morpheConfig := compile.DefaultMorpheCompileConfig("registry", "output")
+	entries.ApplyTo(&morpheConfig)
+
+	suite.Nil(morpheConfig.MorpheConfig.Validate())
+	suite.Equal(godef.Package{Path: "github.com/kalo-build/project/domain/models", Name: "domain"}, morpheConfig.MorpheModelsConfig.Package)
+	suite.Equal("m", morpheConfig.MorpheModelsConfig.ReceiverName)
+	suite.Equal(cfg.CasingCamel, morpheConfig.MorpheModelsConfig.FieldCasing)
+	suite.True(morpheConfig.MorpheModelsConfig.TypedIDs)
+	suite.True(morpheConfig.MorpheModelsConfig.ORMTags.Gorm)
+
+	suite.Equal("enums", morpheConfig.MorpheEnumsConfig.Package.Name)
+	suite.True(morpheConfig.MorpheEnumsConfig.JSONMarshalling)
+
+	suite.Equal(cfg.CasingSnake, morpheConfig.MorpheStructuresConfig.FieldCasing)
+
+	suite.Equal("ent", morpheConfig.MorpheEntitiesConfig.ReceiverName)
+	suite.Equal(cfg.CasingCamel, morpheConfig.MorpheEntitiesConfig.FieldCasing)
+	suite.True(morpheConfig.MorpheEntitiesConfig.ModelMappers)
+
+	suite.Equal("github.com/kalo-build/project/domain/support", morpheConfig.MorpheSupportConfig.Package.Path)
+	suite.True(morpheConfig.MorpheSupportConfig.CivilDate)
+
+	suite.True(morpheConfig.RemoveStaleFiles)
+}
@@ -0,0 +1,145 @@
+package pluginconfig
+
+import (
+	"github.com/kalo-build/go/pkg/godef"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile"
+	"github.com/kalo-build/plugin-morphe-go-struct/pkg/compile/cfg"
+)
+
+// ApplyTo sets the configured options on a compile config, ie. the one returned by compile.DefaultMorpheCompileConfig.
+// Options that are not set keep the values of the compile config.
+func (entries CompileConfigEntries) ApplyTo(morpheConfig *compile.MorpheCompileConfig) {
+	applyModelsConfig(&morpheConfig.MorpheModelsConfig, entries.Models, entries.FieldCasing)
+	applyEnumsConfig(&morpheConfig.MorpheEnumsConfig, entries.Enums)
+	applyStructuresConfig(&morpheConfig.MorpheStructuresConfig, entries.Structures, entries.FieldCasing)
+	applyEntitiesConfig(&morpheConfig.MorpheEntitiesConfig, entries.Entities, entries.FieldCasing)
+	applySupportConfig(&morpheConfig.MorpheSupportConfig, entries.Support)
+
+	morpheConfig.MorpheTypeOverridesConfig = cfg.MorpheTypeOverridesConfig{
+		FieldTypes:  getTypeOverrides(entries.TypeOverrides.FieldTypes),
+		ModelFields: getTypeOverrides(entries.TypeOverrides.ModelFields),
+	}
+	morpheConfig.RemoveStaleFiles = entries.RemoveStaleFiles
+}
+
+func applyModelsConfig(modelsConfig *cfg.MorpheModelsConfig, models CompileConfigModels, fieldCasing string) {
+	applyPackage(&modelsConfig.Package, models.PackagePath, models.PackageName)
+	applyString(&modelsConfig.ReceiverName, models.ReceiverName)
+	modelsConfig.FieldCasing = getFieldCasing(models.FieldCasing, fieldCasing)
+	modelsConfig.DocComments = models.DocComments
+	modelsConfig.FieldOrder = cfg.FieldOrder(models.FieldOrder)
+	modelsConfig.JSONTags = getJSONTagsConfig(models.JSONTags)
+	modelsConfig.ORMTags = getORMTagsConfig(models.ORMTags)
+	modelsConfig.ValidateTags = models.ValidateTags
+	modelsConfig.ValidateMethods = models.ValidateMethods
+	modelsConfig.TypedIDs = models.TypedIDs
+	modelsConfig.TypedPolyRelations = models.TypedPolyRelations
+	modelsConfig.Constructors = models.Constructors
+	modelsConfig.CloneMethods = models.CloneMethods
+	modelsConfig.DiffMethods = models.DiffMethods
+}
+
+func applyEnumsConfig(enumsConfig *cfg.MorpheEnumsConfig, enums CompileConfigEnums) {
+	applyPackage(&enumsConfig.Package, enums.PackagePath, enums.PackageName)
+	enumsConfig.TextMarshalling = enums.TextMarshalling
+	enumsConfig.JSONMarshalling = enums.JSONMarshalling
+	enumsConfig.SQLMarshalling = enums.SQLMarshalling
+	enumsConfig.DocComments = enums.DocComments
+}
+
+func applyStructuresConfig(structuresConfig *cfg.MorpheStructuresConfig, structures CompileConfigStructures, fieldCasing string) {
+	applyPackage(&structuresConfig.Package, structures.PackagePath, structures.PackageName)
+	applyString(&structuresConfig.ReceiverName, structures.ReceiverName)
+	structuresConfig.FieldCasing = getFieldCasing(structures.FieldCasing, fieldCasing)
+	structuresConfig.DocComments = structures.DocComments
+	structuresConfig.FieldOrder = cfg.FieldOrder(structures.FieldOrder)
+	structuresConfig.JSONTags = getJSONTagsConfig(structures.JSONTags)
+	structuresConfig.ValidateTags = structures.ValidateTags
+	structuresConfig.ValidateMethods = structures.ValidateMethods
+	structuresConfig.CloneMethods = structures.CloneMethods
+}
+
+func applyEntitiesConfig(entitiesConfig *cfg.MorpheEntitiesConfig, entities CompileConfigEntities, fieldCasing string) {
+	applyPackage(&entitiesConfig.Package, entities.PackagePath, entities.PackageName)
+	applyString(&entitiesConfig.ReceiverName, entities.ReceiverName)
+	entitiesConfig.FieldCasing = getFieldCasing(entities.FieldCasing, fieldCasing)
+	entitiesConfig.DocComments = entities.DocComments
+	entitiesConfig.FieldOrder = cfg.FieldOrder(entities.FieldOrder)
+	entitiesConfig.JSONTags = getJSONTagsConfig(entities.JSONTags)
+	entitiesConfig.ORMTags = getORMTagsConfig(entities.ORMTags)
+	entitiesConfig.ValidateTags = entities.ValidateTags
+	entitiesConfig.ValidateMethods = entities.ValidateMethods
+	entitiesConfig.ModelMappers = entities.ModelMappers
+	entitiesConfig.CloneMethods = entities.CloneMethods
+	entitiesConfig.DiffMethods = entities.DiffMethods
+}
+
+func applySupportConfig(supportConfig *cfg.MorpheSupportConfig, support CompileConfigSupport) {
+	applyPackage(&supportConfig.Package, support.PackagePath, support.PackageName)
+	supportConfig.CivilDate = support.CivilDate
+	supportConfig.DateType = godef.GoTypeStruct{
+		PackagePath: support.DateType.PackagePath,
+		Name:        support.DateType.Name,
+	}
+	supportConfig.RedactSecrets = support.RedactSecrets
+}
+
+func applyPackage(pkg *godef.Package, packagePath string, packageName string) {
+	applyString(&pkg.Path, packagePath)
+	applyString(&pkg.Name, packageName)
+}
+
+func applyString(target *string, value string) {
+	if value != "" {
+		*target = value
+	}
+}
+
+// getFieldCasing returns the casing of a section, falling back to the casing shared by all sections.
+func getFieldCasing(sectionCasing string, fieldCasing string) cfg.Casing {
+	if sectionCasing != "" {
+		return cfg.Casing(sectionCasing)
+	}
+	return cfg.Casing(fieldCasing)
+}
+
+func getJSONTagsConfig(jsonTags CompileConfigJSONTags) cfg.JSONTagsConfig {
+	jsonTagsConfig := cfg.JSONTagsConfig{
+		OmitOptional: cfg.JSONOmit(jsonTags.OmitOptional),
+		Relations:    cfg.JSONRelations(jsonTags.Relations),
+	}
+	if len(jsonTags.Fields) == 0 {
+		return jsonTagsConfig
+	}
+	jsonTagsConfig.Fields = map[string]cfg.JSONFieldOverride{}
+	for fieldPath, fieldOverride := range jsonTags.Fields {
+		jsonTagsConfig.Fields[fieldPath] = cfg.JSONFieldOverride{
+			Name:   fieldOverride.Name,
+			String: fieldOverride.String,
+		}
+	}
+	return jsonTagsConfig
+}
+
+func getORMTagsConfig(ormTags CompileConfigORMTags) cfg.ORMTagsConfig {
+	return cfg.ORMTagsConfig{
+		DB:           ormTags.DB,
+		Gorm:         ormTags.Gorm,
+		Bun:          ormTags.Bun,
+		ColumnCasing: cfg.Casing(ormTags.ColumnCasing),
+	}
+}
+
+func getTypeOverrides(typeOverrides map[string]CompileConfigTypeOverride) map[string]cfg.TypeOverride {
+	if len(typeOverrides) == 0 {
+		return nil
+	}
+	allOverrides := map[string]cfg.TypeOverride{}
+	for overrideKey, typeOverride := range typeOverrides {
+		allOverrides[overrideKey] = cfg.TypeOverride{
+			PackagePath: typeOverride.PackagePath,
+			Name:        typeOverride.Name,
+		}
+	}
+	return allOverrides
+}
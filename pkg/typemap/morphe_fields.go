@@ -31,3 +31,43 @@ var MorpheStructureFieldToGoField = map[yaml.StructureFieldType]godef.GoType{
 	yaml.StructureFieldTypeProtected:     godef.GoTypeString,
 	yaml.StructureFieldTypeSealed:        godef.GoTypeString,
 }
+
+// SupportTypes are the Go types replacing the default mapping of specific Morphe field types, nil types keep the default mapping.
+type SupportTypes struct {
+	// Date replaces time.Time for Date fields
+	Date godef.GoType
+
+	// Protected replaces string for Protected fields
+	Protected godef.GoType
+
+	// Sealed replaces string for Sealed fields
+	Sealed godef.GoType
+}
+
+// GetMorpheModelFieldGoType returns the Go type for the Morphe model field type, taking the support types into account.
+func GetMorpheModelFieldGoType(fieldType yaml.ModelFieldType, supportTypes SupportTypes) (godef.GoType, bool) {
+	switch {
+	case fieldType == yaml.ModelFieldTypeDate && supportTypes.Date != nil:
+		return supportTypes.Date, true
+	case fieldType == yaml.ModelFieldTypeProtected && supportTypes.Protected != nil:
+		return supportTypes.Protected, true
+	case fieldType == yaml.ModelFieldTypeSealed && supportTypes.Sealed != nil:
+		return supportTypes.Sealed, true
+	}
+	goFieldType, typeSupported := MorpheModelFieldToGoField[fieldType]
+	return goFieldType, typeSupported
+}
+
+// GetMorpheStructureFieldGoType returns the Go type for the Morphe structure field type, taking the support types into account.
+func GetMorpheStructureFieldGoType(fieldType yaml.StructureFieldType, supportTypes SupportTypes) (godef.GoType, bool) {
+	switch {
+	case fieldType == yaml.StructureFieldTypeDate && supportTypes.Date != nil:
+		return supportTypes.Date, true
+	case fieldType == yaml.StructureFieldTypeProtected && supportTypes.Protected != nil:
+		return supportTypes.Protected, true
+	case fieldType == yaml.StructureFieldTypeSealed && supportTypes.Sealed != nil:
+		return supportTypes.Sealed, true
+	}
+	goFieldType, typeSupported := MorpheStructureFieldToGoField[fieldType]
+	return goFieldType, typeSupported
+}
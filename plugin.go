@@ -0,0 +1,12 @@
+// Package plugin holds the plugin manifest (plugin.yaml), so that the CLI can validate its config against the
+// declared configSchema. The configSchema is generated from the config structs of pkg/pluginconfig.
+package plugin
+
+//go:generate go run ./cmd/configschema plugin.yaml
+
+import _ "embed"
+
+// Manifest is the contents of plugin.yaml.
+//
+//go:embed plugin.yaml
+var Manifest []byte
@@ -1,3 +1,3 @@
 set GOOS=wasip1
 set GOARCH=wasm
-go build -o ../dist/morphe-go-struct-v1.0.0.wasm ../cmd/plugin/main.go
\ No newline at end of file
+go build -o ../dist/morphe-go-struct-v1.0.0.wasm ../cmd/plugin
\ No newline at end of file
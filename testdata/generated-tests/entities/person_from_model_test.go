@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"testing"
+
+	"generated/models"
+)
+
+func TestPersonFromModel_OptionalFieldNotSet(t *testing.T) {
+	person, mapErr := PersonFromModel(models.Person{ID: 1})
+	if mapErr == nil || mapErr.Error() != "mapping Person.Nickname: model field Person.Nickname is not set" {
+		t.Fatalf("mapped %#v, %v", person, mapErr)
+	}
+}
+
+func TestPersonFromModel_OptionalFieldSet(t *testing.T) {
+	nickname := "Jo"
+	person, mapErr := PersonFromModel(models.Person{ID: 1, Nickname: &nickname})
+	if mapErr != nil {
+		t.Fatal(mapErr)
+	}
+	if person.Nickname != nickname {
+		t.Fatalf("mapped %#v", person)
+	}
+}
@@ -0,0 +1,84 @@
+package enums
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type marshalledEnum interface {
+	comparable
+	encoding.TextMarshaler
+	json.Marshaler
+	driver.Valuer
+}
+
+type unmarshalledEnum[T any] interface {
+	*T
+	encoding.TextUnmarshaler
+	json.Unmarshaler
+	sql.Scanner
+}
+
+// assertRoundTrip checks that text, JSON and SQL encode the value alike and decode it back.
+func assertRoundTrip[T marshalledEnum, P unmarshalledEnum[T]](t *testing.T, value T, expectedText string) {
+	t.Helper()
+
+	text, textErr := value.MarshalText()
+	if textErr != nil || string(text) != expectedText {
+		t.Fatalf("MarshalText(%v) = %q, %v", value, text, textErr)
+	}
+	var textValue T
+	if unmarshalErr := P(&textValue).UnmarshalText(text); unmarshalErr != nil || textValue != value {
+		t.Fatalf("UnmarshalText(%q) = %v, %v", text, textValue, unmarshalErr)
+	}
+
+	jsonData, jsonErr := json.Marshal(value)
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+	var jsonText string
+	if json.Unmarshal(jsonData, &jsonText) != nil {
+		jsonText = string(jsonData)
+	}
+	if jsonText != expectedText {
+		t.Fatalf("MarshalJSON(%v) = %s, text is %q", value, jsonData, expectedText)
+	}
+	var jsonValue T
+	if unmarshalErr := P(&jsonValue).UnmarshalJSON(jsonData); unmarshalErr != nil || jsonValue != value {
+		t.Fatalf("UnmarshalJSON(%s) = %v, %v", jsonData, jsonValue, unmarshalErr)
+	}
+
+	sqlValue, sqlErr := value.Value()
+	if sqlErr != nil || fmt.Sprint(sqlValue) != expectedText {
+		t.Fatalf("Value(%v) = %v, %v", value, sqlValue, sqlErr)
+	}
+	var scannedValue T
+	if scanErr := P(&scannedValue).Scan(sqlValue); scanErr != nil || scannedValue != value {
+		t.Fatalf("Scan(%v) = %v, %v", sqlValue, scannedValue, scanErr)
+	}
+}
+
+func TestEnums_MarshallingRoundTrip(t *testing.T) {
+	assertRoundTrip(t, PriorityHigh, "2")
+	assertRoundTrip(t, PriorityLow, "1")
+	assertRoundTrip(t, ColorRed, "red")
+	assertRoundTrip(t, ColorGreen, "green")
+	assertRoundTrip(t, RatioHalf, "0.5")
+	assertRoundTrip(t, RatioQuarter, "0.25")
+}
+
+func TestEnums_UnmarshalTextEntryName(t *testing.T) {
+	var priority Priority
+	if unmarshalErr := priority.UnmarshalText([]byte("High")); !errors.As(unmarshalErr, &InvalidPriorityError{}) {
+		t.Fatalf("UnmarshalText(High) = %v, %v", priority, unmarshalErr)
+	}
+	var color Color
+	if unmarshalErr := color.UnmarshalText([]byte("Red")); !errors.As(unmarshalErr, &InvalidColorError{}) {
+		t.Fatalf("UnmarshalText(Red) = %v, %v", color, unmarshalErr)
+	}
+}
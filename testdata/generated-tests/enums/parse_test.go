@@ -0,0 +1,44 @@
+package enums
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEnums_Parse(t *testing.T) {
+	if priority, parseErr := ParsePriority("High"); parseErr != nil || priority != PriorityHigh {
+		t.Fatalf("ParsePriority(High) = %v, %v", priority, parseErr)
+	}
+	if color, parseErr := ParseColor("Red"); parseErr != nil || color != ColorRed {
+		t.Fatalf("ParseColor(Red) = %v, %v", color, parseErr)
+	}
+	if ratio, parseErr := ParseRatio("Half"); parseErr != nil || ratio != RatioHalf {
+		t.Fatalf("ParseRatio(Half) = %v, %v", ratio, parseErr)
+	}
+
+	if _, parseErr := ParsePriority("2"); !errors.As(parseErr, &InvalidPriorityError{}) {
+		t.Fatalf("ParsePriority(2) = %v", parseErr)
+	}
+	if _, parseErr := ParseColor("red"); !errors.As(parseErr, &InvalidColorError{}) {
+		t.Fatalf("ParseColor(red) = %v", parseErr)
+	}
+}
+
+func TestEnums_FromValue(t *testing.T) {
+	if priority, fromValueErr := PriorityFromValue(2); fromValueErr != nil || priority != PriorityHigh {
+		t.Fatalf("PriorityFromValue(2) = %v, %v", priority, fromValueErr)
+	}
+	if color, fromValueErr := ColorFromValue("red"); fromValueErr != nil || color != ColorRed {
+		t.Fatalf("ColorFromValue(red) = %v, %v", color, fromValueErr)
+	}
+	if ratio, fromValueErr := RatioFromValue(0.5); fromValueErr != nil || ratio != RatioHalf {
+		t.Fatalf("RatioFromValue(0.5) = %v, %v", ratio, fromValueErr)
+	}
+
+	if _, fromValueErr := PriorityFromValue(3); !errors.As(fromValueErr, &InvalidPriorityError{}) {
+		t.Fatalf("PriorityFromValue(3) = %v", fromValueErr)
+	}
+	if _, fromValueErr := ColorFromValue("Red"); !errors.As(fromValueErr, &InvalidColorError{}) {
+		t.Fatalf("ColorFromValue(Red) = %v", fromValueErr)
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestComment_CloneTypedPolyRelationPointer(t *testing.T) {
+	person := &Person{ID: 1, FirstName: "Ada"}
+	comment := Comment{ID: 1}
+	comment.SetCommentable(person)
+
+	clone := comment.Clone()
+
+	clonedPerson, isPerson := clone.Commentable.(*Person)
+	if !isPerson {
+		t.Fatalf("unexpected commentable %#v", clone.Commentable)
+	}
+	if clonedPerson == person {
+		t.Fatalf("commentable %p is shared with the original", clonedPerson)
+	}
+	clonedPerson.FirstName = "Bob"
+	if person.FirstName != "Ada" {
+		t.Fatalf("original commentable changed to %q", person.FirstName)
+	}
+	if clone.CommentableType != CommentableTypePerson || clone.CommentableID != "1" {
+		t.Fatalf("unexpected commentable link %q %q", clone.CommentableType, clone.CommentableID)
+	}
+}
+
+func TestComment_CloneTypedPolyRelationValue(t *testing.T) {
+	company := Company{ID: 2, Name: "Acme", PersonIDs: []uint{1, 2}}
+	comment := Comment{ID: 1}
+	comment.SetCommentable(company)
+
+	clone := comment.Clone()
+
+	clonedCompany, isCompany := clone.Commentable.(Company)
+	if !isCompany {
+		t.Fatalf("unexpected commentable %#v", clone.Commentable)
+	}
+	clonedCompany.PersonIDs[0] = 3
+	if company.PersonIDs[0] != 1 {
+		t.Fatalf("original commentable IDs changed to %v", company.PersonIDs)
+	}
+}
+
+func TestComment_CloneTypedPolyRelationNil(t *testing.T) {
+	clone := Comment{ID: 1}.Clone()
+
+	if clone.Commentable != nil {
+		t.Fatalf("unexpected commentable %#v", clone.Commentable)
+	}
+}
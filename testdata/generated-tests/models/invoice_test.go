@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+
+	"generated/money"
+)
+
+func TestInvoice_EqualTypeOverride(t *testing.T) {
+	discount := money.NewAmount("0.50")
+	otherDiscount := money.NewAmount("1/2")
+	invoice := Invoice{ID: 1, Amount: money.NewAmount("10.00"), Discount: &discount}
+	other := Invoice{ID: 1, Amount: money.NewAmount("10"), Discount: &otherDiscount}
+	if !invoice.Equal(other) {
+		t.Fatalf("%#v is not equal to %#v", invoice, other)
+	}
+	if changes := invoice.Diff(other); len(changes) != 0 {
+		t.Fatalf("unexpected changes %#v", changes)
+	}
+}
+
+func TestInvoice_DiffTypeOverride(t *testing.T) {
+	invoice := Invoice{ID: 1, Amount: money.NewAmount("10")}
+	other := Invoice{ID: 1, Amount: money.NewAmount("11")}
+	if invoice.Equal(other) {
+		t.Fatalf("%#v is equal to %#v", invoice, other)
+	}
+	changes := invoice.Diff(other)
+	if len(changes) != 1 || changes[0].Field != "Amount" {
+		t.Fatalf("unexpected changes %#v", changes)
+	}
+}
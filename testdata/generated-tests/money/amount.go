@@ -0,0 +1,17 @@
+package money
+
+import "math/big"
+
+// Amount is compared with Equal, == compares the pointers.
+type Amount struct {
+	Value *big.Rat
+}
+
+func NewAmount(value string) Amount {
+	rat, _ := new(big.Rat).SetString(value)
+	return Amount{Value: rat}
+}
+
+func (a Amount) Equal(b Amount) bool {
+	return a.Value.Cmp(b.Value) == 0
+}
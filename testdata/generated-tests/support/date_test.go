@@ -0,0 +1,83 @@
+package support
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type dateHolder struct {
+	Date Date `json:"date"`
+}
+
+func TestDate_JSONRoundTrip(t *testing.T) {
+	for _, date := range []Date{{}, {Year: 2024, Month: time.February, Day: 29}} {
+		dateJSON, marshalErr := json.Marshal(dateHolder{Date: date})
+		if marshalErr != nil {
+			t.Fatalf("marshal %#v: %v", date, marshalErr)
+		}
+		var holder dateHolder
+		if unmarshalErr := json.Unmarshal(dateJSON, &holder); unmarshalErr != nil {
+			t.Fatalf("unmarshal %s: %v", dateJSON, unmarshalErr)
+		}
+		if holder.Date != date {
+			t.Fatalf("round trip of %#v returned %#v", date, holder.Date)
+		}
+	}
+}
+
+func TestDate_ZeroJSON(t *testing.T) {
+	dateJSON, marshalErr := json.Marshal(Date{})
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	if string(dateJSON) != "null" {
+		t.Fatalf("zero Date marshalled as %s", dateJSON)
+	}
+	var date Date
+	if unmarshalErr := json.Unmarshal([]byte(`""`), &date); unmarshalErr != nil || !date.IsZero() {
+		t.Fatalf("empty string unmarshalled as %#v: %v", date, unmarshalErr)
+	}
+}
+
+func TestDate_TextRoundTrip(t *testing.T) {
+	for _, date := range []Date{{}, {Year: 1999, Month: time.December, Day: 31}} {
+		dateText, marshalErr := date.MarshalText()
+		if marshalErr != nil {
+			t.Fatalf("marshal %#v: %v", date, marshalErr)
+		}
+		var parsedDate Date
+		if unmarshalErr := parsedDate.UnmarshalText(dateText); unmarshalErr != nil {
+			t.Fatalf("unmarshal %q: %v", dateText, unmarshalErr)
+		}
+		if parsedDate != date {
+			t.Fatalf("round trip of %#v returned %#v", date, parsedDate)
+		}
+	}
+}
+
+func TestDate_SQLRoundTrip(t *testing.T) {
+	zeroValue, zeroErr := Date{}.Value()
+	if zeroErr != nil || zeroValue != nil {
+		t.Fatalf("zero Date value is %#v: %v", zeroValue, zeroErr)
+	}
+
+	date := Date{Year: 2024, Month: time.March, Day: 1}
+	for _, value := range []any{zeroValue, mustValue(t, date)} {
+		var scannedDate Date
+		if scanErr := scannedDate.Scan(value); scanErr != nil {
+			t.Fatalf("scan %#v: %v", value, scanErr)
+		}
+		if value == nil && !scannedDate.IsZero() || value != nil && scannedDate != date {
+			t.Fatalf("scan of %#v returned %#v", value, scannedDate)
+		}
+	}
+}
+
+func mustValue(t *testing.T, date Date) any {
+	value, valueErr := date.Value()
+	if valueErr != nil {
+		t.Fatal(valueErr)
+	}
+	return value
+}
@@ -0,0 +1,54 @@
+package support
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type secretHolder struct {
+	Password Protected `json:"password"`
+	Token    Sealed    `json:"token"`
+}
+
+func TestSecret_MarshalRedacts(t *testing.T) {
+	holder := secretHolder{
+		Password: NewProtected("hunter2"),
+		Token:    NewSealed("token"),
+	}
+	holderJSON, marshalErr := json.Marshal(holder)
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	if string(holderJSON) != `{"password":"[REDACTED]","token":"[REDACTED]"}` {
+		t.Fatalf("secrets marshalled as %s", holderJSON)
+	}
+	if formatted := fmt.Sprintf("%v %+v %#v %s", holder.Password, holder, holder.Token, holder.Token); formatted != "[REDACTED] {Password:[REDACTED] Token:[REDACTED]} [REDACTED] [REDACTED]" {
+		t.Fatalf("secrets formatted as %s", formatted)
+	}
+}
+
+func TestSecret_UnmarshalRejectsRedacted(t *testing.T) {
+	holderJSON, marshalErr := json.Marshal(secretHolder{Password: NewProtected("hunter2")})
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	var holder secretHolder
+	if unmarshalErr := json.Unmarshal(holderJSON, &holder); !errors.Is(unmarshalErr, ErrRedacted) {
+		t.Fatalf("unmarshalling %s returned %v", holderJSON, unmarshalErr)
+	}
+	if holder.Password.Raw() == Redacted {
+		t.Fatal("the redacted placeholder became the raw value")
+	}
+}
+
+func TestSecret_UnmarshalRawValue(t *testing.T) {
+	var holder secretHolder
+	if unmarshalErr := json.Unmarshal([]byte(`{"password":"hunter2","token":null}`), &holder); unmarshalErr != nil {
+		t.Fatal(unmarshalErr)
+	}
+	if holder.Password.Raw() != "hunter2" || !holder.Token.IsZero() {
+		t.Fatalf("unmarshalled %q and %q", holder.Password.Raw(), holder.Token.Raw())
+	}
+}
@@ -1,5 +1,11 @@
+// Code generated by plugin-morphe-go-struct. DO NOT EDIT.
+
 package enums
 
+import (
+	"fmt"
+)
+
 type Nationality string
 
 const (
@@ -7,3 +13,44 @@ const (
 	NationalityFr Nationality = "French"
 	NationalityUs Nationality = "American"
 )
+
+// AllNationalityValues returns all declared Nationality values.
+func AllNationalityValues() []Nationality {
+	return []Nationality{
+		NationalityDe,
+		NationalityFr,
+		NationalityUs,
+	}
+}
+
+// IsValid returns true if the value is one of the declared Nationality values.
+func (e Nationality) IsValid() bool {
+	for _, enumValue := range AllNationalityValues() {
+		if e == enumValue {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseNationality returns the Nationality with the given entry name, or an error if no such entry is declared.
+func ParseNationality(name string) (Nationality, error) {
+	switch name {
+	case "DE":
+		return NationalityDe, nil
+	case "FR":
+		return NationalityFr, nil
+	case "US":
+		return NationalityUs, nil
+	}
+	return "", fmt.Errorf("invalid Nationality name: %#v", name)
+}
+
+// NationalityFromValue returns the Nationality with the given value, or an error if the value is not declared.
+func NationalityFromValue(value string) (Nationality, error) {
+	enumValue := Nationality(value)
+	if !enumValue.IsValid() {
+		return "", fmt.Errorf("invalid Nationality value: %#v", value)
+	}
+	return enumValue, nil
+}
@@ -1,8 +1,63 @@
+// Code generated by plugin-morphe-go-struct. DO NOT EDIT.
+
 package enums
 
+import (
+	"fmt"
+)
+
 type UniversalNumber float64
 
 const (
 	UniversalNumberEuler UniversalNumber = 2.7182818285
 	UniversalNumberPi    UniversalNumber = 3.1415926535
 )
+
+// AllUniversalNumberValues returns all declared UniversalNumber values.
+func AllUniversalNumberValues() []UniversalNumber {
+	return []UniversalNumber{
+		UniversalNumberEuler,
+		UniversalNumberPi,
+	}
+}
+
+// IsValid returns true if the value is one of the declared UniversalNumber values.
+func (e UniversalNumber) IsValid() bool {
+	for _, enumValue := range AllUniversalNumberValues() {
+		if e == enumValue {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the entry name of the UniversalNumber value.
+func (e UniversalNumber) String() string {
+	switch e {
+	case UniversalNumberEuler:
+		return "Euler"
+	case UniversalNumberPi:
+		return "Pi"
+	}
+	return fmt.Sprintf("UniversalNumber(%v)", float64(e))
+}
+
+// ParseUniversalNumber returns the UniversalNumber with the given entry name, or an error if no such entry is declared.
+func ParseUniversalNumber(name string) (UniversalNumber, error) {
+	switch name {
+	case "Euler":
+		return UniversalNumberEuler, nil
+	case "Pi":
+		return UniversalNumberPi, nil
+	}
+	return 0, fmt.Errorf("invalid UniversalNumber name: %#v", name)
+}
+
+// UniversalNumberFromValue returns the UniversalNumber with the given value, or an error if the value is not declared.
+func UniversalNumberFromValue(value float64) (UniversalNumber, error) {
+	enumValue := UniversalNumber(value)
+	if !enumValue.IsValid() {
+		return 0, fmt.Errorf("invalid UniversalNumber value: %#v", value)
+	}
+	return enumValue, nil
+}
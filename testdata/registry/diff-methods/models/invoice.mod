@@ -0,0 +1,12 @@
+name: Invoice
+fields:
+  ID:
+    type: AutoIncrement
+  Amount:
+    type: String
+  Discount:
+    type: String
+    attributes:
+      - optional
+identifiers:
+  primary: ID
@@ -0,0 +1,12 @@
+name: Company
+description: Company is an organization employing people.
+fields:
+  ID:
+    type: AutoIncrement
+  Name:
+    type: String
+identifiers:
+  primary: ID
+related:
+  Person:
+    type: HasMany
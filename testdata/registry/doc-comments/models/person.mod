@@ -0,0 +1,27 @@
+name: Person
+description: A person known to the system.
+fields:
+  ID:
+    type: AutoIncrement
+  FirstName:
+    type: String
+    description: |
+      Given name of the person.
+      Used in salutations.
+  LastName:
+    type: String
+  Nationality:
+    type: Nationality
+identifiers:
+  primary: ID
+  name:
+    - FirstName
+    - LastName
+related:
+  Company:
+    type: ForOne
+    description: The employer of the person.
+  Note:
+    type: HasManyPoly
+    through: Commentable
+    aliased: Comment
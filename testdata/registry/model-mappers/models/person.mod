@@ -0,0 +1,10 @@
+name: Person
+fields:
+  ID:
+    type: AutoIncrement
+  Nickname:
+    type: String
+    attributes:
+      - optional
+identifiers:
+  primary: ID